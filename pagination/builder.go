@@ -1,19 +1,25 @@
 package pagination
 
-import "math"
+import (
+	"math"
+	"net/url"
+	"strconv"
+)
 
 // Build creates pagination from parameters with smart defaults
 func Build(page, limit, total int) *Pagination {
+	return BuildWith(currentConfig(), page, limit, total)
+}
+
+// BuildWith creates pagination using cfg instead of the global defaults
+// e.g. for export endpoints that allow a larger MaxLimit
+// When total is 0, TotalPages is 0 and Page is the requested page (or 0 with
+// cfg.ZeroPageWhenEmpty); HasNext and HasPrev are both false.
+func BuildWith(cfg PaginationConfig, page, limit, total int) *Pagination {
 	// Smart defaults - handle all edge cases
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 {
-		limit = 10
-	}
-	if limit > 100 {
-		limit = 100 // Prevent abuse
-	}
+	cfg = cfg.normalized()
+	page = cfg.page(page)
+	limit = cfg.limit(limit)
 	if total < 0 {
 		total = 0
 	}
@@ -24,6 +30,10 @@ func Build(page, limit, total int) *Pagination {
 	}
 
 	offset := max((page-1)*limit, 0)
+	if total == 0 && cfg.ZeroPageWhenEmpty {
+		page = 0
+		offset = 0
+	}
 
 	return &Pagination{
 		Page:       page,
@@ -40,13 +50,18 @@ func Quick(params DefaultQueryParams, total int) *Pagination {
 	return Build(params.Page, params.Limit, total)
 }
 
+// IsEmpty reports whether the result set has no items
+func (p *Pagination) IsEmpty() bool {
+	return p.Total == 0
+}
+
 // Optional helper methods
 func (p *Pagination) HasNext() bool {
 	return p.Page < p.TotalPages
 }
 
 func (p *Pagination) HasPrev() bool {
-	return p.Page > 1
+	return !p.IsEmpty() && p.Page > 1
 }
 
 func (p *Pagination) NextPage() int {
@@ -63,27 +78,48 @@ func (p *Pagination) PrevPage() int {
 	return p.Page
 }
 
-// SetDefaults applies default values to query params with smart validation
-func (q *DefaultQueryParams) SetDefaults() {
-	if q.Page < 1 {
-		q.Page = 1
+// Links builds self/first/last/next/prev URLs from baseURL
+// Existing query params on baseURL are preserved; next/prev are omitted when
+// there is no such page. Returns nil if baseURL can't be parsed.
+func (p *Pagination) Links(baseURL string) map[string]string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil
 	}
-	if q.Limit < 1 {
-		q.Limit = 10
+
+	pageURL := func(page int) string {
+		query := u.Query()
+		query.Set("page", strconv.Itoa(page))
+		query.Set("limit", strconv.Itoa(p.Limit))
+		link := *u
+		link.RawQuery = query.Encode()
+		return link.String()
 	}
-	if q.Limit > 100 {
-		q.Limit = 100 // Prevent abuse
+
+	links := map[string]string{
+		"self":  pageURL(p.Page),
+		"first": pageURL(1),
+		"last":  pageURL(max(p.TotalPages, 1)),
 	}
-	if q.SortBy == "" {
-		q.SortBy = "created_at"
+	if p.HasNext() {
+		links["next"] = pageURL(p.NextPage())
 	}
-	if q.SortOrder == "" {
-		q.SortOrder = "desc"
+	if p.HasPrev() {
+		links["prev"] = pageURL(p.PrevPage())
 	}
-	// Normalize sort order
-	if q.SortOrder != "asc" && q.SortOrder != "desc" {
-		q.SortOrder = "desc"
+
+	return links
+}
+
+// SetDefaults applies default values to query params with smart validation
+func (q *DefaultQueryParams) SetDefaults() {
+	cfg := currentConfig()
+	q.Page = cfg.page(q.Page)
+	q.Limit = cfg.limit(q.Limit)
+	if q.SortBy == "" {
+		q.SortBy = cfg.DefaultSortBy
 	}
+	q.SortOrder = cfg.sortOrder(q.SortOrder)
 }
 
 // GetOffset calculates offset for database queries
@@ -0,0 +1,35 @@
+package pagination
+
+import "testing"
+
+func TestBuildWithEmptyResults(t *testing.T) {
+	cases := []struct {
+		name       string
+		zeroPage   bool
+		page       int
+		wantPage   int
+		wantOffset int
+	}{
+		{"echoes requested page", false, 3, 3, 20},
+		{"zero page when empty", true, 3, 0, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := DefaultPaginationConfig()
+			cfg.ZeroPageWhenEmpty = tc.zeroPage
+
+			p := BuildWith(cfg, tc.page, 10, 0)
+
+			if p.Page != tc.wantPage || p.Offset != tc.wantOffset {
+				t.Errorf("Page, Offset = %d, %d, want %d, %d", p.Page, p.Offset, tc.wantPage, tc.wantOffset)
+			}
+			if p.Limit != 10 || p.Total != 0 || p.TotalPages != 0 {
+				t.Errorf("Limit, Total, TotalPages = %d, %d, %d, want 10, 0, 0", p.Limit, p.Total, p.TotalPages)
+			}
+			if !p.IsEmpty() || p.HasNext() || p.HasPrev() {
+				t.Errorf("IsEmpty, HasNext, HasPrev = %v, %v, %v, want true, false, false", p.IsEmpty(), p.HasNext(), p.HasPrev())
+			}
+		})
+	}
+}
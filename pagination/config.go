@@ -0,0 +1,133 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrLimitExceeded is returned in strict mode when limit is above MaxLimit
+var ErrLimitExceeded = errors.New("limit exceeds maximum")
+
+// PaginationConfig controls pagination defaults and limits
+type PaginationConfig struct {
+	DefaultPage  int
+	DefaultLimit int
+	MaxLimit     int
+
+	// Fallback sort when the request has none (default created_at desc)
+	DefaultSortBy    string
+	DefaultSortOrder string
+
+	// ZeroPageWhenEmpty reports Page 0 instead of the requested page when
+	// total is 0, so empty results read as "page 0 of 0"
+	ZeroPageWhenEmpty bool
+}
+
+// DefaultPaginationConfig returns the built-in defaults
+func DefaultPaginationConfig() PaginationConfig {
+	return PaginationConfig{
+		DefaultPage:      1,
+		DefaultLimit:     10,
+		MaxLimit:         100,
+		DefaultSortBy:    "created_at",
+		DefaultSortOrder: "desc",
+	}
+}
+
+var (
+	globalConfig   = DefaultPaginationConfig()
+	globalConfigMu sync.RWMutex
+)
+
+// SetDefaults replaces the global defaults used by Build, Quick, SmartBind and
+// the SetDefaults methods. Call it once at startup; zero values keep the built-in defaults.
+func SetDefaults(cfg PaginationConfig) {
+	globalConfigMu.Lock()
+	defer globalConfigMu.Unlock()
+	globalConfig = cfg.normalized()
+}
+
+// SetDefaultSort changes the fallback sort used when a request has none,
+// for tables without a created_at column. order must be "asc" or "desc".
+func SetDefaultSort(by, order string) {
+	globalConfigMu.Lock()
+	defer globalConfigMu.Unlock()
+	globalConfig.DefaultSortBy = by
+	globalConfig.DefaultSortOrder = order
+	globalConfig = globalConfig.normalized()
+}
+
+// currentConfig returns the global defaults
+func currentConfig() PaginationConfig {
+	globalConfigMu.RLock()
+	defer globalConfigMu.RUnlock()
+	return globalConfig
+}
+
+// normalized fills unset or inconsistent values with built-in defaults
+func (cfg PaginationConfig) normalized() PaginationConfig {
+	defaults := DefaultPaginationConfig()
+	if cfg.DefaultPage < 1 {
+		cfg.DefaultPage = defaults.DefaultPage
+	}
+	if cfg.MaxLimit < 1 {
+		cfg.MaxLimit = defaults.MaxLimit
+	}
+	if cfg.DefaultLimit < 1 {
+		cfg.DefaultLimit = min(defaults.DefaultLimit, cfg.MaxLimit)
+	}
+	if cfg.DefaultLimit > cfg.MaxLimit {
+		cfg.DefaultLimit = cfg.MaxLimit
+	}
+	if cfg.DefaultSortBy == "" {
+		cfg.DefaultSortBy = defaults.DefaultSortBy
+	}
+	if cfg.DefaultSortOrder != "asc" && cfg.DefaultSortOrder != "desc" {
+		cfg.DefaultSortOrder = defaults.DefaultSortOrder
+	}
+	return cfg
+}
+
+// page applies the default page when page is out of range
+func (cfg PaginationConfig) page(page int) int {
+	if page < 1 {
+		return cfg.DefaultPage
+	}
+	return page
+}
+
+// sortOrder applies the default order when order isn't asc or desc
+func (cfg PaginationConfig) sortOrder(order string) string {
+	if order != "asc" && order != "desc" {
+		return cfg.DefaultSortOrder
+	}
+	return order
+}
+
+// limit applies the default limit and caps it at MaxLimit
+func (cfg PaginationConfig) limit(limit int) int {
+	if limit < 1 {
+		return cfg.DefaultLimit
+	}
+	if limit > cfg.MaxLimit {
+		return cfg.MaxLimit // Prevent abuse
+	}
+	return limit
+}
+
+// ValidateLimit checks limit against the global MaxLimit without clamping
+// Zero or negative limits are accepted, they fall back to DefaultLimit.
+func ValidateLimit(limit int) error {
+	if maxLimit := currentConfig().MaxLimit; limit > maxLimit {
+		return fmt.Errorf("%w of %d", ErrLimitExceeded, maxLimit)
+	}
+	return nil
+}
+
+// StrictLimit rejects limits above MaxLimit instead of silently capping them
+func StrictLimit() BindOption {
+	return func(c *bindConfig) {
+		c.strictLimit = true
+	}
+}
@@ -0,0 +1,170 @@
+package pagination
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidCursor is returned when a cursor token can't be decoded or verified
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorSecret signs cursor tokens when set
+var (
+	cursorSecret   []byte
+	cursorSecretMu sync.RWMutex
+)
+
+// SetCursorSecret enables HMAC signing of cursor tokens so clients can't forge keys
+// Call it once at startup; pass nil to disable signing. The secret is copied.
+func SetCursorSecret(secret []byte) {
+	cursorSecretMu.Lock()
+	defer cursorSecretMu.Unlock()
+	cursorSecret = bytes.Clone(secret)
+}
+
+// currentCursorSecret returns the signing secret, nil when signing is off
+func currentCursorSecret() []byte {
+	cursorSecretMu.RLock()
+	defer cursorSecretMu.RUnlock()
+	return cursorSecret
+}
+
+// cursorPayload is the JSON body of a cursor token
+type cursorPayload struct {
+	Direction CursorDirection `json:"d"`
+	Keys      map[string]any  `json:"k"`
+}
+
+// BuildCursor creates cursor pagination from a page of items
+// Fetch params.Limit+1 rows so the extra row signals another page, and pass
+// items in display order; the returned slice is trimmed to params.Limit.
+// encodeFn returns the sort key of an item.
+//
+// For a next cursor (or no cursor) the extra row is the last item: NextCursor
+// is set when it exists and PrevCursor when the request carried a cursor.
+// For a prev cursor the rows come from before the cursor, so the extra row is
+// the first item: NextCursor is always set and PrevCursor only when it exists.
+func BuildCursor[T any](items []T, params CursorQueryParams, encodeFn func(item T) map[string]any) (*CursorPage, []T, error) {
+	params.SetDefaults()
+
+	_, direction, err := DecodeCursorWithDirection(params.Cursor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := &CursorPage{Limit: params.Limit}
+
+	hasMore := len(items) > params.Limit
+	hasNext, hasPrev := hasMore, params.Cursor != ""
+	if direction == CursorPrev {
+		hasNext, hasPrev = true, hasMore
+		if hasMore {
+			items = items[len(items)-params.Limit:]
+		}
+	} else if hasMore {
+		items = items[:params.Limit]
+	}
+
+	if len(items) == 0 {
+		return page, items, nil
+	}
+
+	if hasNext {
+		next, err := EncodeCursor(encodeFn(items[len(items)-1]), CursorNext)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.NextCursor = next
+	}
+
+	if hasPrev {
+		prev, err := EncodeCursor(encodeFn(items[0]), CursorPrev)
+		if err != nil {
+			return nil, nil, err
+		}
+		page.PrevCursor = prev
+	}
+
+	return page, items, nil
+}
+
+// EncodeCursor creates an opaque cursor token for the given sort keys
+func EncodeCursor(keys map[string]any, direction CursorDirection) (string, error) {
+	raw, err := json.Marshal(cursorPayload{Direction: direction, Keys: keys})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	if secret := currentCursorSecret(); len(secret) > 0 {
+		token += "." + signCursor(secret, token)
+	}
+	return token, nil
+}
+
+// DecodeCursor returns the sort keys stored in a cursor token
+// Numbers are decoded as float64, following encoding/json
+func DecodeCursor(token string) (map[string]any, error) {
+	keys, _, err := DecodeCursorWithDirection(token)
+	return keys, err
+}
+
+// DecodeCursorWithDirection returns the sort keys and paging direction of a cursor token
+// An empty token means the first page and decodes to no keys
+func DecodeCursorWithDirection(token string) (map[string]any, CursorDirection, error) {
+	if token == "" {
+		return nil, CursorNext, nil
+	}
+
+	payload, signature, signed := strings.Cut(token, ".")
+
+	if secret := currentCursorSecret(); len(secret) > 0 {
+		if !signed || !hmac.Equal([]byte(signature), []byte(signCursor(secret, payload))) {
+			return nil, "", fmt.Errorf("%w: signature mismatch", ErrInvalidCursor)
+		}
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var decoded cursorPayload
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	if decoded.Direction != CursorPrev {
+		decoded.Direction = CursorNext
+	}
+	return decoded.Keys, decoded.Direction, nil
+}
+
+// signCursor computes the HMAC signature of an encoded payload
+func signCursor(secret []byte, payload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Optional helper methods
+func (p *CursorPage) HasNext() bool {
+	return p.NextCursor != ""
+}
+
+func (p *CursorPage) HasPrev() bool {
+	return p.PrevCursor != ""
+}
+
+// SetDefaults applies default values to cursor query params
+func (q *CursorQueryParams) SetDefaults() {
+	q.Limit = currentConfig().limit(q.Limit)
+	q.Cursor = strings.TrimSpace(q.Cursor)
+}
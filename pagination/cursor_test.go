@@ -0,0 +1,93 @@
+package pagination
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func cursorKey(id int) map[string]any {
+	return map[string]any{"id": id}
+}
+
+func cursorID(t *testing.T, token string, want CursorDirection) int {
+	t.Helper()
+	keys, direction, err := DecodeCursorWithDirection(token)
+	if err != nil {
+		t.Fatalf("decode %q: %v", token, err)
+	}
+	if direction != want {
+		t.Fatalf("direction = %q, want %q", direction, want)
+	}
+	return int(keys["id"].(float64))
+}
+
+func TestBuildCursorNext(t *testing.T) {
+	token, _ := EncodeCursor(cursorKey(3), CursorNext)
+	page, items, err := BuildCursor([]int{4, 5, 6}, CursorQueryParams{Cursor: token, Limit: 2}, cursorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(items, []int{4, 5}) {
+		t.Errorf("items = %v, want [4 5]", items)
+	}
+	if id := cursorID(t, page.NextCursor, CursorNext); id != 5 {
+		t.Errorf("next cursor id = %d, want 5", id)
+	}
+	if id := cursorID(t, page.PrevCursor, CursorPrev); id != 4 {
+		t.Errorf("prev cursor id = %d, want 4", id)
+	}
+}
+
+func TestBuildCursorPrev(t *testing.T) {
+	token, _ := EncodeCursor(cursorKey(7), CursorPrev)
+
+	// Extra row at the front: more pages before this one
+	page, items, err := BuildCursor([]int{4, 5, 6}, CursorQueryParams{Cursor: token, Limit: 2}, cursorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(items, []int{5, 6}) {
+		t.Errorf("items = %v, want [5 6]", items)
+	}
+	if id := cursorID(t, page.NextCursor, CursorNext); id != 6 {
+		t.Errorf("next cursor id = %d, want 6", id)
+	}
+	if id := cursorID(t, page.PrevCursor, CursorPrev); id != 5 {
+		t.Errorf("prev cursor id = %d, want 5", id)
+	}
+
+	// No extra row: this is the first page
+	page, items, err = BuildCursor([]int{5, 6}, CursorQueryParams{Cursor: token, Limit: 2}, cursorKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(items, []int{5, 6}) {
+		t.Errorf("items = %v, want [5 6]", items)
+	}
+	if !page.HasNext() || page.HasPrev() {
+		t.Errorf("HasNext = %v, HasPrev = %v, want true, false", page.HasNext(), page.HasPrev())
+	}
+}
+
+func TestSetCursorSecretCopiesSecret(t *testing.T) {
+	secret := []byte("s3cret")
+	SetCursorSecret(secret)
+	defer SetCursorSecret(nil)
+
+	token, err := EncodeCursor(cursorKey(1), CursorNext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret[0] = 'x' // must not change the stored secret
+	if _, err := DecodeCursor(token); err != nil {
+		t.Errorf("DecodeCursor after caller changed its slice: %v", err)
+	}
+
+	SetCursorSecret([]byte("other"))
+	if _, err := DecodeCursor(token); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("DecodeCursor with another secret: err = %v, want ErrInvalidCursor", err)
+	}
+}
@@ -8,14 +8,38 @@ import (
 )
 
 // SmartBind - binds query params and auto-applies defaults
-func SmartBind(c *gin.Context, params *DefaultQueryParams) error {
+// Use WithAllowedSorts to whitelist SortBy columns and StrictLimit to reject
+// limits above MaxLimit (by default they are capped)
+func SmartBind(c *gin.Context, params *DefaultQueryParams, options ...BindOption) error {
 	if err := c.ShouldBindQuery(params); err != nil {
 		return fmt.Errorf("invalid query parameters: %w", err)
 	}
+
+	config := &bindConfig{}
+	for _, opt := range options {
+		opt(config)
+	}
+
+	if config.strictLimit {
+		if err := ValidateLimit(params.Limit); err != nil {
+			return fmt.Errorf("invalid query parameters: %w", err)
+		}
+	}
+	requestedSort := params.SortBy
 	params.SetDefaults()
+
+	if err := config.applySort(params, requestedSort); err != nil {
+		return fmt.Errorf("invalid query parameters: %w", err)
+	}
 	return nil
 }
 
+// SmartBindStrict is SmartBind with StrictLimit
+// Requests above MaxLimit get an error wrapping ErrLimitExceeded instead of being capped
+func SmartBindStrict(c *gin.Context, params *DefaultQueryParams, options ...BindOption) error {
+	return SmartBind(c, params, append(options, StrictLimit())...)
+}
+
 // BindAndSetDefaults - helper function to bind any struct and apply defaults
 // Works with existing DTO structs
 func BindAndSetDefaults(c *gin.Context, req any) error {
@@ -39,27 +63,20 @@ func ApplyDefaultsToStruct(req interface{}) {
 	}
 
 	// Apply defaults to common pagination fields
+	cfg := currentConfig()
 	if pageField := val.FieldByName("Page"); pageField.IsValid() && pageField.CanSet() && pageField.Int() < 1 {
-		pageField.SetInt(1)
+		pageField.SetInt(int64(cfg.DefaultPage))
 	}
 
 	if limitField := val.FieldByName("Limit"); limitField.IsValid() && limitField.CanSet() {
-		if limitField.Int() < 1 {
-			limitField.SetInt(10)
-		} else if limitField.Int() > 100 {
-			limitField.SetInt(100)
-		}
+		limitField.SetInt(int64(cfg.limit(int(limitField.Int()))))
 	}
 
 	if sortByField := val.FieldByName("SortBy"); sortByField.IsValid() && sortByField.CanSet() && sortByField.String() == "" {
-		sortByField.SetString("created_at")
+		sortByField.SetString(cfg.DefaultSortBy)
 	}
 
 	if sortOrderField := val.FieldByName("SortOrder"); sortOrderField.IsValid() && sortOrderField.CanSet() {
-		if sortOrderField.String() == "" {
-			sortOrderField.SetString("desc")
-		} else if sortOrderField.String() != "asc" && sortOrderField.String() != "desc" {
-			sortOrderField.SetString("desc")
-		}
+		sortOrderField.SetString(cfg.sortOrder(sortOrderField.String()))
 	}
 }
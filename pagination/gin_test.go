@@ -0,0 +1,41 @@
+package pagination
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func bindQuery(t *testing.T, query string, options ...BindOption) (DefaultQueryParams, error) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/items?"+query, nil)
+
+	var params DefaultQueryParams
+	err := SmartBind(c, &params, options...)
+	return params, err
+}
+
+func TestSmartBindStrictSort(t *testing.T) {
+	options := []BindOption{WithAllowedSorts("name", "price"), StrictSort()}
+
+	params, err := bindQuery(t, "", options...)
+	if err != nil {
+		t.Fatalf("no sortBy: unexpected error %v", err)
+	}
+	if params.SortBy != "name" {
+		t.Errorf("no sortBy: SortBy = %q, want fallback %q", params.SortBy, "name")
+	}
+
+	params, err = bindQuery(t, "sortBy=price", options...)
+	if err != nil || params.SortBy != "price" {
+		t.Errorf("sortBy=price: got %q, %v", params.SortBy, err)
+	}
+
+	if _, err = bindQuery(t, "sortBy=secret", options...); !errors.Is(err, ErrInvalidSort) {
+		t.Errorf("sortBy=secret: err = %v, want ErrInvalidSort", err)
+	}
+}
@@ -0,0 +1,109 @@
+// ==================== pagination/hybrid.go ====================
+package pagination
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Strategy is the pagination style chosen for a request
+type Strategy string
+
+const (
+	StrategyOffset Strategy = "offset"
+	StrategyCursor Strategy = "cursor"
+)
+
+// ErrConflictingPagination is returned when a request mixes cursor and page
+var ErrConflictingPagination = errors.New("cursor and page cannot be used together")
+
+// HybridQueryParams accepts either cursor or page/limit, so one endpoint can
+// serve both while clients migrate from offset to cursor pagination.
+// Clients wanting cursors from the first page send mode=cursor.
+type HybridQueryParams struct {
+	Cursor    string `form:"cursor" json:"cursor" binding:"omitempty"`
+	Page      int    `form:"page" json:"page" binding:"omitempty"`
+	Limit     int    `form:"limit" json:"limit" binding:"omitempty"`
+	Mode      string `form:"mode" json:"mode" binding:"omitempty"`
+	Search    string `form:"search" json:"search" binding:"omitempty"`
+	SortBy    string `form:"sortBy" json:"sortBy" binding:"omitempty"`
+	SortOrder string `form:"sortOrder" json:"sortOrder" binding:"omitempty"`
+}
+
+// Detect returns cursor when a cursor or mode=cursor was sent, offset otherwise
+func (q *HybridQueryParams) Detect() Strategy {
+	if q.Cursor != "" || Strategy(q.Mode) == StrategyCursor {
+		return StrategyCursor
+	}
+	return StrategyOffset
+}
+
+// Validate rejects requests that supply both a cursor and a page, or an unknown mode
+func (q *HybridQueryParams) Validate() error {
+	switch Strategy(q.Mode) {
+	case "", StrategyOffset, StrategyCursor:
+	default:
+		return fmt.Errorf("invalid pagination mode %q", q.Mode)
+	}
+
+	if q.Cursor != "" && (q.Page > 0 || Strategy(q.Mode) == StrategyOffset) {
+		return ErrConflictingPagination
+	}
+	if Strategy(q.Mode) == StrategyCursor && q.Page > 0 {
+		return ErrConflictingPagination
+	}
+	return nil
+}
+
+// OffsetParams returns the offset view of the params, with defaults applied
+func (q *HybridQueryParams) OffsetParams() DefaultQueryParams {
+	params := DefaultQueryParams{
+		Page:      q.Page,
+		Limit:     q.Limit,
+		Search:    q.Search,
+		SortBy:    q.SortBy,
+		SortOrder: q.SortOrder,
+	}
+	params.SetDefaults()
+	return params
+}
+
+// CursorParams returns the cursor view of the params, with defaults applied
+func (q *HybridQueryParams) CursorParams() CursorQueryParams {
+	params := CursorQueryParams{
+		Cursor: q.Cursor,
+		Limit:  q.Limit,
+	}
+	params.SetDefaults()
+	return params
+}
+
+// BuildHybrid builds whichever pagination applies to params
+// It returns a *Pagination (from total) for offset requests or a *CursorPage
+// (from items, fetched with Limit+1 rows) for cursor requests, ready to be
+// used as meta pagination. total is ignored for cursor requests.
+func BuildHybrid[T any](items []T, params HybridQueryParams, total int, encodeFn func(item T) map[string]any) (any, []T, error) {
+	if err := params.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if params.Detect() == StrategyCursor {
+		return BuildCursor(items, params.CursorParams(), encodeFn)
+	}
+
+	offset := params.OffsetParams()
+	return Build(offset.Page, offset.Limit, total), items, nil
+}
+
+// SmartBindHybrid binds hybrid query params and validates them
+func SmartBindHybrid(c *gin.Context, params *HybridQueryParams) error {
+	if err := c.ShouldBindQuery(params); err != nil {
+		return fmt.Errorf("invalid query parameters: %w", err)
+	}
+	if err := params.Validate(); err != nil {
+		return fmt.Errorf("invalid query parameters: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// ==================== pagination/range.go ====================
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RangeUnit is the unit used in Range / Content-Range headers
+const RangeUnit = "items"
+
+// ErrInvalidRange is returned for malformed Range headers
+var ErrInvalidRange = errors.New("invalid range header")
+
+// ParseRangeHeader reads "Range: items=0-24" (React-Admin / simple-rest style)
+// Limit is the window size capped at MaxLimit, Offset the first item and Page
+// the page that contains it. "items=10-" uses the default limit; no header
+// gives the first page with defaults. Total is left 0 until the count is known.
+//
+// Offset is authoritative: ranges need not be aligned to the window size, so
+// for "items=5-14" Page is 1 while Offset is 5, unlike Build where Offset is
+// always (Page-1)*Limit. Query with Offset and Limit, and treat Page as
+// approximate.
+func ParseRangeHeader(r *http.Request) (*Pagination, error) {
+	cfg := currentConfig()
+
+	header := strings.TrimSpace(r.Header.Get("Range"))
+	if header == "" {
+		return BuildWith(cfg, cfg.DefaultPage, cfg.DefaultLimit, 0), nil
+	}
+
+	unit, spec, ok := strings.Cut(header, "=")
+	if !ok || strings.TrimSpace(unit) != RangeUnit || strings.Contains(spec, ",") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRange, header)
+	}
+
+	startText, endText, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRange, header)
+	}
+
+	start, err := strconv.Atoi(startText)
+	if err != nil || start < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRange, header)
+	}
+
+	limit := cfg.DefaultLimit
+	if endText != "" {
+		end, err := strconv.Atoi(endText)
+		if err != nil || end < start {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRange, header)
+		}
+		// Cap before adding 1 so huge ranges can't overflow
+		if end-start >= cfg.MaxLimit {
+			limit = cfg.MaxLimit
+		} else {
+			limit = cfg.limit(end - start + 1)
+		}
+	}
+
+	return &Pagination{
+		Page:   start/limit + 1,
+		Limit:  limit,
+		Offset: start,
+	}, nil
+}
+
+// SetRangeHeaders sets Accept-Ranges and "Content-Range: items 0-24/319"
+// count is the number of items returned; with no items the range is "*".
+// Browsers only expose Content-Range to scripts when it's listed in
+// Access-Control-Expose-Headers.
+func SetRangeHeaders(header http.Header, p *Pagination, count, total int) {
+	header.Set("Accept-Ranges", RangeUnit)
+
+	if count <= 0 {
+		header.Set("Content-Range", fmt.Sprintf("%s */%d", RangeUnit, total))
+		return
+	}
+	header.Set("Content-Range", fmt.Sprintf("%s %d-%d/%d", RangeUnit, p.Offset, p.Offset+count-1, total))
+}
@@ -0,0 +1,32 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseRangeHeader(t *testing.T) {
+	cases := []struct {
+		header              string
+		page, limit, offset int
+	}{
+		{"items=0-9", 1, 10, 0},
+		{"items=20-29", 3, 10, 20},
+		{"items=5-14", 1, 10, 5},                   // unaligned: Offset is authoritative
+		{"items=0-9223372036854775807", 1, 100, 0}, // capped at MaxLimit, no overflow
+	}
+
+	for _, tc := range cases {
+		r := httptest.NewRequest("GET", "/items", nil)
+		r.Header.Set("Range", tc.header)
+
+		p, err := ParseRangeHeader(r)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.header, err)
+		}
+		if p.Page != tc.page || p.Limit != tc.limit || p.Offset != tc.offset {
+			t.Errorf("%s: page, limit, offset = %d, %d, %d, want %d, %d, %d",
+				tc.header, p.Page, p.Limit, p.Offset, tc.page, tc.limit, tc.offset)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidSort is returned when SortBy is not in the allowed columns
+var ErrInvalidSort = errors.New("invalid sort field")
+
+// ValidateSort checks sortBy against a whitelist of sortable columns
+// SortOrder is normalized by SetDefaults, but SortBy is passed through as-is,
+// so whitelist it before interpolating into ORDER BY.
+// On failure it returns the fallback column (the default sort column, see
+// SetDefaultSort, when allowed, otherwise the first allowed column) together
+// with the error.
+// An empty whitelist accepts any value.
+func ValidateSort(sortBy string, allowed []string) (string, error) {
+	sortBy = strings.TrimSpace(sortBy)
+	if len(allowed) == 0 || slices.Contains(allowed, sortBy) {
+		return sortBy, nil
+	}
+
+	fallback := allowed[0]
+	if defaultSort := currentConfig().DefaultSortBy; slices.Contains(allowed, defaultSort) {
+		fallback = defaultSort
+	}
+
+	return fallback, fmt.Errorf("%w: %q is not sortable", ErrInvalidSort, sortBy)
+}
+
+// BindOption configures SmartBind
+type BindOption func(*bindConfig)
+
+// bindConfig holds SmartBind options
+type bindConfig struct {
+	allowedSorts []string
+	strictSort   bool
+	strictLimit  bool
+}
+
+// WithAllowedSorts restricts SortBy to the given columns
+// Unknown columns fall back to the default unless StrictSort is set
+func WithAllowedSorts(columns ...string) BindOption {
+	return func(c *bindConfig) {
+		c.allowedSorts = columns
+	}
+}
+
+// StrictSort rejects unknown sort columns instead of falling back
+func StrictSort() BindOption {
+	return func(c *bindConfig) {
+		c.strictSort = true
+	}
+}
+
+// applySort validates the client's sortBy against the configured whitelist
+// StrictSort only rejects a column the client sent; when none was sent the
+// default column is used if allowed, otherwise the ValidateSort fallback.
+func (c *bindConfig) applySort(params *DefaultQueryParams, requested string) error {
+	if strings.TrimSpace(requested) == "" {
+		params.SortBy, _ = ValidateSort(params.SortBy, c.allowedSorts)
+		return nil
+	}
+
+	sortBy, err := ValidateSort(requested, c.allowedSorts)
+	if err != nil && c.strictSort {
+		return err
+	}
+	params.SortBy = sortBy
+	return nil
+}
@@ -3,11 +3,11 @@ package pagination
 
 // Pagination represents pagination information
 type Pagination struct {
-	Page       int `json:"page"`
-	Limit      int `json:"limit"`
-	Total      int `json:"totalItems"`
-	TotalPages int `json:"totalPages"`
-	Offset     int `json:"offset"`
+	Page       int `json:"page" xml:"page"`
+	Limit      int `json:"limit" xml:"limit"`
+	Total      int `json:"totalItems" xml:"totalItems"`
+	TotalPages int `json:"totalPages" xml:"totalPages"`
+	Offset     int `json:"offset" xml:"offset"`
 }
 
 // DefaultQueryParams for parsing pagination from request
@@ -18,3 +18,24 @@ type DefaultQueryParams struct {
 	SortBy    string `form:"sortBy" json:"sortBy" binding:"omitempty"`
 	SortOrder string `form:"sortOrder" json:"sortOrder" binding:"omitempty"`
 }
+
+// CursorPage represents cursor-based pagination information
+type CursorPage struct {
+	Limit      int    `json:"limit" xml:"limit"`
+	NextCursor string `json:"nextCursor,omitempty" xml:"nextCursor,omitempty"`
+	PrevCursor string `json:"prevCursor,omitempty" xml:"prevCursor,omitempty"`
+}
+
+// CursorQueryParams for parsing cursor pagination from request
+type CursorQueryParams struct {
+	Cursor string `form:"cursor" json:"cursor" binding:"omitempty"`
+	Limit  int    `form:"limit" json:"limit" binding:"omitempty"`
+}
+
+// CursorDirection tells which way a cursor pages
+type CursorDirection string
+
+const (
+	CursorNext CursorDirection = "next"
+	CursorPrev CursorDirection = "prev"
+)
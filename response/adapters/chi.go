@@ -0,0 +1,33 @@
+// ==================== response/adapters/chi.go ====================
+package adapters
+
+import (
+	"net/http"
+
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// ChiContextExtractor extracts context from a chi-routed *http.Request
+// Path is the matched route pattern (e.g. /users/{id}) so logs group by route;
+// it falls back to the raw URL path outside a chi router.
+func ChiContextExtractor(req any) *response.Context {
+	ctx := StdContextExtractor(req)
+
+	if r, ok := req.(*http.Request); ok {
+		if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil {
+			if pattern := routeCtx.RoutePattern(); pattern != "" {
+				ctx.Path = pattern
+			}
+		}
+	}
+
+	return ctx
+}
+
+// NewChiHandler creates a response handler for chi routers
+// Use it with the net/http helpers (Error, OK, Created, OKWithPagination)
+func NewChiHandler(options ...response.Option) *response.Handler {
+	options = append([]response.Option{response.WithContextExtractor(ChiContextExtractor)}, options...)
+	return response.NewHandler(options...)
+}
@@ -0,0 +1,111 @@
+// ==================== response/adapters/echo.go ====================
+package adapters
+
+import (
+	"io"
+
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/labstack/echo/v4"
+)
+
+// EchoJSONWriter implements JSONWriter for Echo framework
+type EchoJSONWriter struct {
+	ctx echo.Context
+}
+
+func (e *EchoJSONWriter) JSON(statusCode int, obj any) {
+	_ = e.ctx.JSON(statusCode, obj)
+}
+
+func (e *EchoJSONWriter) XML(statusCode int, obj any) {
+	_ = e.ctx.XML(statusCode, obj)
+}
+
+func (e *EchoJSONWriter) Stream(statusCode int, contentType string, body io.Reader) error {
+	return e.ctx.Stream(statusCode, contentType, body)
+}
+
+func (e *EchoJSONWriter) StreamChunks(statusCode int, contentType string, step func(w io.Writer) bool) {
+	res := e.ctx.Response()
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.WriteHeader(statusCode)
+	for step(res) {
+		res.Flush()
+	}
+}
+
+func (e *EchoJSONWriter) Status(statusCode int) {
+	_ = e.ctx.NoContent(statusCode)
+}
+
+func (e *EchoJSONWriter) SetHeader(key, value string) {
+	e.ctx.Response().Header().Set(key, value)
+}
+
+// EchoContextExtractor extracts context from Echo request
+func EchoContextExtractor(req any) *response.Context {
+	if echoCtx, ok := req.(echo.Context); ok {
+		request := echoCtx.Request()
+		return &response.Context{
+			Path:      request.URL.Path,
+			Method:    request.Method,
+			ClientIP:  echoCtx.RealIP(),
+			UserAgent: request.UserAgent(),
+			UserID:    firstNonEmpty(echoString(echoCtx, "user_id"), response.UserIDFromContext(request.Context())),
+			TraceID:   firstNonEmpty(echoString(echoCtx, "trace_id"), response.TraceIDFromContext(request.Context())),
+			Accept:    request.Header.Get(echo.HeaderAccept),
+			StartTime: response.StartTimeFromContext(request.Context()),
+		}
+	}
+	return &response.Context{}
+}
+
+// echoString reads a string value stored with echo.Context.Set
+func echoString(c echo.Context, key string) string {
+	if value, ok := c.Get(key).(string); ok {
+		return value
+	}
+	return ""
+}
+
+// EchoHandler wraps the core handler with Echo-friendly helpers
+type EchoHandler struct {
+	handler *response.Handler
+}
+
+// NewEchoHandler creates a response handler for Echo
+// Options are applied after the Echo context extractor, so they can override it
+func NewEchoHandler(options ...response.Option) *EchoHandler {
+	options = append([]response.Option{response.WithContextExtractor(EchoContextExtractor)}, options...)
+	return &EchoHandler{handler: response.NewHandler(options...)}
+}
+
+// ============ RESPONSE FUNCTIONS ============
+// Each helper returns nil so it can be used directly as an echo.HandlerFunc result
+
+func (h *EchoHandler) HandleError(c echo.Context, err error, opts ...response.CallOption) error {
+	h.handler.HandleError(&EchoJSONWriter{ctx: c}, c, err, opts...)
+	return nil
+}
+
+func (h *EchoHandler) OK(c echo.Context, message string, data any, opts ...response.CallOption) error {
+	h.handler.OK(&EchoJSONWriter{ctx: c}, c, message, data, opts...)
+	return nil
+}
+
+func (h *EchoHandler) Created(c echo.Context, message string, data any, opts ...response.CallOption) error {
+	h.handler.Created(&EchoJSONWriter{ctx: c}, c, message, data, opts...)
+	return nil
+}
+
+// Deleted sends 200 OK with "data": null
+func (h *EchoHandler) Deleted(c echo.Context, message string, opts ...response.CallOption) error {
+	h.handler.Deleted(&EchoJSONWriter{ctx: c}, c, message, opts...)
+	return nil
+}
+
+// OKWithPagination sends success response with pagination
+func (h *EchoHandler) OKWithPagination(c echo.Context, message string, data any, pagination any, opts ...response.CallOption) error {
+	h.handler.OKWithPagination(&EchoJSONWriter{ctx: c}, c, message, data, pagination, opts...)
+	return nil
+}
@@ -0,0 +1,54 @@
+package adapters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/labstack/echo/v4"
+)
+
+func requestWithIDs() *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+	ctx := response.ContextWithTraceID(r.Context(), "trace-1")
+	ctx = response.ContextWithUserID(ctx, "user-1")
+	return r.WithContext(ctx)
+}
+
+func TestExtractorsFallBackToRequestContext(t *testing.T) {
+	extractors := map[string]func() *response.Context{
+		"std": func() *response.Context { return StdContextExtractor(requestWithIDs()) },
+		"chi": func() *response.Context { return ChiContextExtractor(requestWithIDs()) },
+		"echo": func() *response.Context {
+			return EchoContextExtractor(echo.New().NewContext(requestWithIDs(), httptest.NewRecorder()))
+		},
+	}
+
+	for name, extract := range extractors {
+		ctx := extract()
+		if ctx.TraceID != "trace-1" || ctx.UserID != "user-1" {
+			t.Errorf("%s: TraceID, UserID = %q, %q, want trace-1, user-1", name, ctx.TraceID, ctx.UserID)
+		}
+	}
+}
+
+func TestStdContextExtractorTraceIDHeaderFallback(t *testing.T) {
+	r := requestWithIDs()
+	r.Header.Set("X-Request-Id", "header-1")
+
+	if traceID := StdContextExtractor(r).TraceID; traceID != "trace-1" {
+		t.Errorf("TraceID = %q, want context value trace-1", traceID)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/users", nil)
+	r.Header.Set("X-Request-Id", "header-1")
+	if traceID := StdContextExtractor(r).TraceID; traceID != "header-1" {
+		t.Errorf("TraceID = %q, want header-1", traceID)
+	}
+
+	r.Header.Set("X-Request-Id", "forged\nlevel=error")
+	if traceID := StdContextExtractor(r).TraceID; traceID != "" {
+		t.Errorf("TraceID = %q, want unsafe header dropped", traceID)
+	}
+}
@@ -0,0 +1,129 @@
+// ==================== response/adapters/fiber.go ====================
+package adapters
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+// FiberJSONWriter implements JSONWriter for Fiber framework
+// Serialization errors are swallowed, same as EchoJSONWriter, because
+// JSONWriter has no way to report them and the status is already committed
+type FiberJSONWriter struct {
+	ctx         *fiber.Ctx
+	contentType string
+}
+
+func (f *FiberJSONWriter) JSON(statusCode int, obj any) {
+	// Fiber always overwrites Content-Type on JSON, so pass any custom one through
+	if f.contentType != "" {
+		_ = f.ctx.Status(statusCode).JSON(obj, f.contentType)
+		return
+	}
+	_ = f.ctx.Status(statusCode).JSON(obj)
+}
+
+func (f *FiberJSONWriter) XML(statusCode int, obj any) {
+	_ = f.ctx.Status(statusCode).XML(obj)
+}
+
+func (f *FiberJSONWriter) Stream(statusCode int, contentType string, body io.Reader) error {
+	f.ctx.Set(fiber.HeaderContentType, contentType)
+	return f.ctx.Status(statusCode).SendStream(body)
+}
+
+// StreamChunks runs step after the handler returns, as fasthttp streams the
+// body once the response is being sent
+func (f *FiberJSONWriter) StreamChunks(statusCode int, contentType string, step func(w io.Writer) bool) {
+	f.ctx.Set(fiber.HeaderContentType, contentType)
+	f.ctx.Status(statusCode).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		for step(w) {
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+		_ = w.Flush()
+	})
+}
+
+func (f *FiberJSONWriter) Status(statusCode int) {
+	f.ctx.Status(statusCode)
+}
+
+func (f *FiberJSONWriter) SetHeader(key, value string) {
+	if strings.EqualFold(key, fiber.HeaderContentType) {
+		f.contentType = value
+	}
+	f.ctx.Set(key, value)
+}
+
+// FiberContextExtractor extracts context from Fiber request
+func FiberContextExtractor(req any) *response.Context {
+	if fiberCtx, ok := req.(*fiber.Ctx); ok {
+		return &response.Context{
+			Path:      fiberCtx.Path(),
+			Method:    fiberCtx.Method(),
+			ClientIP:  fiberCtx.IP(),
+			UserAgent: fiberCtx.Get(fiber.HeaderUserAgent),
+			UserID:    firstNonEmpty(fiberString(fiberCtx, "user_id"), response.UserIDFromContext(fiberCtx.UserContext())),
+			TraceID:   firstNonEmpty(fiberString(fiberCtx, "trace_id"), response.TraceIDFromContext(fiberCtx.UserContext())),
+			Accept:    fiberCtx.Get(fiber.HeaderAccept),
+			StartTime: response.StartTimeFromContext(fiberCtx.UserContext()),
+		}
+	}
+	return &response.Context{}
+}
+
+// fiberString reads a string value stored with fiber.Ctx.Locals
+func fiberString(c *fiber.Ctx, key string) string {
+	if value, ok := c.Locals(key).(string); ok {
+		return value
+	}
+	return ""
+}
+
+// FiberHandler wraps the core handler with Fiber-friendly helpers
+type FiberHandler struct {
+	handler *response.Handler
+}
+
+// NewFiberHandler creates a response handler for Fiber
+// Options are applied after the Fiber context extractor, so they can override it
+func NewFiberHandler(options ...response.Option) *FiberHandler {
+	options = append([]response.Option{response.WithContextExtractor(FiberContextExtractor)}, options...)
+	return &FiberHandler{handler: response.NewHandler(options...)}
+}
+
+// ============ RESPONSE FUNCTIONS ============
+// Each helper returns nil so it can be used directly as a fiber.Handler result
+
+func (h *FiberHandler) HandleError(c *fiber.Ctx, err error, opts ...response.CallOption) error {
+	h.handler.HandleError(&FiberJSONWriter{ctx: c}, c, err, opts...)
+	return nil
+}
+
+func (h *FiberHandler) OK(c *fiber.Ctx, message string, data any, opts ...response.CallOption) error {
+	h.handler.OK(&FiberJSONWriter{ctx: c}, c, message, data, opts...)
+	return nil
+}
+
+func (h *FiberHandler) Created(c *fiber.Ctx, message string, data any, opts ...response.CallOption) error {
+	h.handler.Created(&FiberJSONWriter{ctx: c}, c, message, data, opts...)
+	return nil
+}
+
+// Deleted sends 200 OK with "data": null
+func (h *FiberHandler) Deleted(c *fiber.Ctx, message string, opts ...response.CallOption) error {
+	h.handler.Deleted(&FiberJSONWriter{ctx: c}, c, message, opts...)
+	return nil
+}
+
+// OKWithPagination sends success response with pagination
+func (h *FiberHandler) OKWithPagination(c *fiber.Ctx, message string, data any, pagination any, opts ...response.CallOption) error {
+	h.handler.OKWithPagination(&FiberJSONWriter{ctx: c}, c, message, data, pagination, opts...)
+	return nil
+}
@@ -0,0 +1,141 @@
+// ==================== response/adapters/nethttp.go ====================
+package adapters
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/fiqrioemry/go-api-toolkit/response"
+)
+
+// StdJSONWriter implements JSONWriter for net/http
+type StdJSONWriter struct {
+	w http.ResponseWriter
+}
+
+// NewStdJSONWriter wraps an http.ResponseWriter
+func NewStdJSONWriter(w http.ResponseWriter) *StdJSONWriter {
+	return &StdJSONWriter{w: w}
+}
+
+func (s *StdJSONWriter) JSON(statusCode int, obj any) {
+	if s.w.Header().Get("Content-Type") == "" {
+		s.w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	}
+	s.w.WriteHeader(statusCode)
+	_ = json.NewEncoder(s.w).Encode(obj)
+}
+
+func (s *StdJSONWriter) XML(statusCode int, obj any) {
+	if s.w.Header().Get("Content-Type") == "" {
+		s.w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	}
+	s.w.WriteHeader(statusCode)
+	_, _ = s.w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(s.w).Encode(obj)
+}
+
+func (s *StdJSONWriter) Stream(statusCode int, contentType string, body io.Reader) error {
+	s.w.Header().Set("Content-Type", contentType)
+	s.w.WriteHeader(statusCode)
+	_, err := io.Copy(s.w, body)
+	return err
+}
+
+func (s *StdJSONWriter) StreamChunks(statusCode int, contentType string, step func(w io.Writer) bool) {
+	s.w.Header().Set("Content-Type", contentType)
+	s.w.WriteHeader(statusCode)
+	flusher, _ := s.w.(http.Flusher)
+	for step(s.w) {
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *StdJSONWriter) Status(statusCode int) {
+	s.w.WriteHeader(statusCode)
+}
+
+func (s *StdJSONWriter) SetHeader(key, value string) {
+	s.w.Header().Set(key, value)
+}
+
+// StdContextExtractor extracts context from *http.Request
+// UserID and TraceID come from the request context (see response.ContextWithUserID
+// and response.ContextWithTraceID); TraceID falls back to a sanitized X-Request-Id header
+func StdContextExtractor(req any) *response.Context {
+	if r, ok := req.(*http.Request); ok {
+		return &response.Context{
+			Path:      r.URL.Path,
+			Method:    r.Method,
+			ClientIP:  clientIP(r),
+			UserAgent: r.UserAgent(),
+			UserID:    response.UserIDFromContext(r.Context()),
+			TraceID:   firstNonEmpty(response.TraceIDFromContext(r.Context()), response.SanitizeTraceID(r.Header.Get("X-Request-Id"))),
+			Accept:    r.Header.Get("Accept"),
+			StartTime: response.StartTimeFromContext(r.Context()),
+		}
+	}
+	return &response.Context{}
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// clientIP resolves the client address, preferring proxy headers
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		ip, _, _ := strings.Cut(forwarded, ",")
+		return strings.TrimSpace(ip)
+	}
+	if realIP := r.Header.Get("X-Real-Ip"); realIP != "" {
+		return realIP
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// NewStdHandler creates a response handler for net/http
+// Options are applied after the net/http context extractor, so they can override it
+func NewStdHandler(options ...response.Option) *response.Handler {
+	options = append([]response.Option{response.WithContextExtractor(StdContextExtractor)}, options...)
+	return response.NewHandler(options...)
+}
+
+// ============ RESPONSE FUNCTIONS ============
+
+func Error(h *response.Handler, w http.ResponseWriter, r *http.Request, err error, opts ...response.CallOption) {
+	h.HandleError(NewStdJSONWriter(w), r, err, opts...)
+}
+
+func OK(h *response.Handler, w http.ResponseWriter, r *http.Request, message string, data any, opts ...response.CallOption) {
+	h.OK(NewStdJSONWriter(w), r, message, data, opts...)
+}
+
+func Created(h *response.Handler, w http.ResponseWriter, r *http.Request, message string, data any, opts ...response.CallOption) {
+	h.Created(NewStdJSONWriter(w), r, message, data, opts...)
+}
+
+// Deleted sends 200 OK with "data": null
+func Deleted(h *response.Handler, w http.ResponseWriter, r *http.Request, message string, opts ...response.CallOption) {
+	h.Deleted(NewStdJSONWriter(w), r, message, opts...)
+}
+
+// OKWithPagination sends success response with pagination
+func OKWithPagination(h *response.Handler, w http.ResponseWriter, r *http.Request, message string, data any, pagination any, opts ...response.CallOption) {
+	h.OKWithPagination(NewStdJSONWriter(w), r, message, data, pagination, opts...)
+}
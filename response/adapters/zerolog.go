@@ -0,0 +1,47 @@
+// ==================== response/adapters/zerolog.go ====================
+package adapters
+
+import (
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/rs/zerolog"
+)
+
+// ZerologLogger implements Logger interface for Zerolog
+type ZerologLogger struct {
+	logger zerolog.Logger
+}
+
+func NewZerologLogger(l zerolog.Logger) *ZerologLogger {
+	return &ZerologLogger{logger: l}
+}
+
+func (z *ZerologLogger) Debug(msg string, fields ...response.LogField) {
+	z.log(z.logger.Debug(), msg, fields)
+}
+
+func (z *ZerologLogger) Info(msg string, fields ...response.LogField) {
+	z.log(z.logger.Info(), msg, fields)
+}
+
+func (z *ZerologLogger) Warn(msg string, fields ...response.LogField) {
+	z.log(z.logger.Warn(), msg, fields)
+}
+
+func (z *ZerologLogger) Error(msg string, fields ...response.LogField) {
+	z.log(z.logger.Error(), msg, fields)
+}
+
+// log attaches fields to the event and sends it
+// event is nil when the level is disabled, which zerolog handles safely
+func (z *ZerologLogger) log(event *zerolog.Event, msg string, fields []response.LogField) {
+	event.Fields(z.convertFields(fields)).Msg(msg)
+}
+
+// convertFields flattens fields into the key/value slice accepted by Event.Fields
+func (z *ZerologLogger) convertFields(fields []response.LogField) []any {
+	keyValues := make([]any, 0, len(fields)*2)
+	for _, field := range fields {
+		keyValues = append(keyValues, field.Key, field.Value)
+	}
+	return keyValues
+}
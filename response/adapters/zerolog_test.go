@@ -0,0 +1,46 @@
+package adapters
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/rs/zerolog"
+)
+
+func TestZerologLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewZerologLogger(zerolog.New(&buf).Level(zerolog.InfoLevel))
+
+	logger.Debug("dropped")
+	logger.Warn("Client error occurred",
+		response.LogField{Key: "error_code", Value: "NOT_FOUND"},
+		response.LogField{Key: "status", Value: 404},
+	)
+	logger.Error("Server error occurred", response.LogField{Key: "path", Value: "/users"})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2 (debug filtered):\n%s", len(lines), buf.String())
+	}
+
+	var warn, errEntry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &warn); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &errEntry); err != nil {
+		t.Fatal(err)
+	}
+
+	if warn["level"] != "warn" || warn["message"] != "Client error occurred" {
+		t.Errorf("warn entry = %v", warn)
+	}
+	if warn["error_code"] != "NOT_FOUND" || warn["status"] != float64(404) {
+		t.Errorf("warn fields = %v", warn)
+	}
+	if errEntry["level"] != "error" || errEntry["path"] != "/users" {
+		t.Errorf("error entry = %v", errEntry)
+	}
+}
@@ -0,0 +1,134 @@
+// ==================== response/console.go ====================
+package response
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+// ConsoleLogger implements Logger with human-readable, optionally colorized
+// lines for local development:
+//
+//	15:04:05.000 WARN  Client error occurred path=/users method=GET error_code=NOT_FOUND
+type ConsoleLogger struct {
+	mu       sync.Mutex
+	out      io.Writer
+	level    LogLevel
+	color    bool
+	colorSet bool // color was chosen with WithConsoleColor
+}
+
+// ConsoleOption configures ConsoleLogger
+type ConsoleOption func(*ConsoleLogger)
+
+// WithConsoleWriter sets the output (default os.Stderr)
+func WithConsoleWriter(w io.Writer) ConsoleOption {
+	return func(l *ConsoleLogger) {
+		l.out = w
+	}
+}
+
+// WithConsoleColor enables or disables ANSI colors
+// By default colors are on when the output is a terminal and the NO_COLOR
+// environment variable is unset
+func WithConsoleColor(enabled bool) ConsoleOption {
+	return func(l *ConsoleLogger) {
+		l.color = enabled
+		l.colorSet = true
+	}
+}
+
+// NewConsoleLogger creates a console logger that drops entries below level
+func NewConsoleLogger(level LogLevel, options ...ConsoleOption) *ConsoleLogger {
+	l := &ConsoleLogger{
+		out:   os.Stderr,
+		level: level,
+	}
+	for _, opt := range options {
+		opt(l)
+	}
+	if !l.colorSet {
+		l.color = os.Getenv("NO_COLOR") == "" && isTerminal(l.out)
+	}
+	return l
+}
+
+// isTerminal reports whether w is a terminal, so redirected output stays plain
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+func (l *ConsoleLogger) Debug(msg string, fields ...LogField) {
+	l.log(LogLevelDebug, msg, fields)
+}
+
+func (l *ConsoleLogger) Info(msg string, fields ...LogField) {
+	l.log(LogLevelInfo, msg, fields)
+}
+
+func (l *ConsoleLogger) Warn(msg string, fields ...LogField) {
+	l.log(LogLevelWarn, msg, fields)
+}
+
+func (l *ConsoleLogger) Error(msg string, fields ...LogField) {
+	l.log(LogLevelError, msg, fields)
+}
+
+// consoleLevels holds the label and ANSI color of each level
+var consoleLevels = map[LogLevel]struct{ label, color string }{
+	LogLevelDebug: {"DEBUG", "\033[90m"},
+	LogLevelInfo:  {"INFO ", "\033[36m"},
+	LogLevelWarn:  {"WARN ", "\033[33m"},
+	LogLevelError: {"ERROR", "\033[31m"},
+}
+
+const consoleReset = "\033[0m"
+
+func (l *ConsoleLogger) log(level LogLevel, msg string, fields []LogField) {
+	if level < l.level {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(time.Now().Format("15:04:05.000"))
+	sb.WriteByte(' ')
+
+	style := consoleLevels[level]
+	if l.color {
+		sb.WriteString(style.color + style.label + consoleReset)
+	} else {
+		sb.WriteString(style.label)
+	}
+	sb.WriteByte(' ')
+	sb.WriteString(msg)
+
+	// Multi-line values such as stack traces go below the line, indented
+	var blocks []string
+	for _, field := range fields {
+		value := fmt.Sprint(field.Value)
+		if strings.Contains(value, "\n") {
+			blocks = append(blocks, field.Key+":\n\t"+strings.ReplaceAll(strings.TrimRight(value, "\n"), "\n", "\n\t"))
+			continue
+		}
+		if value == "" || strings.ContainsAny(value, " \t\"=") {
+			value = strconv.Quote(value)
+		}
+		sb.WriteString(" " + field.Key + "=" + value)
+	}
+	sb.WriteByte('\n')
+	for _, block := range blocks {
+		sb.WriteString(block + "\n")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = io.WriteString(l.out, sb.String())
+}
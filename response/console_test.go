@@ -0,0 +1,23 @@
+package response
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConsoleLoggerColorDefault(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	var buf bytes.Buffer
+	NewConsoleLogger(LogLevelInfo, WithConsoleWriter(&buf)).Warn("redirected")
+	if strings.Contains(buf.String(), "\033[") {
+		t.Errorf("non-terminal output is colorized: %q", buf.String())
+	}
+
+	buf.Reset()
+	NewConsoleLogger(LogLevelInfo, WithConsoleWriter(&buf), WithConsoleColor(true)).Warn("forced")
+	if !strings.Contains(buf.String(), "\033[33mWARN") {
+		t.Errorf("WithConsoleColor(true) output is plain: %q", buf.String())
+	}
+}
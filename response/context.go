@@ -0,0 +1,73 @@
+// ==================== response/context.go ====================
+package response
+
+import (
+	"context"
+	"time"
+)
+
+// contextKey is the type of request-scoped values stored in context.Context
+// An unexported type prevents collisions with keys from other packages
+type contextKey string
+
+const (
+	traceIDKey   contextKey = "trace_id"
+	userIDKey    contextKey = "user_id"
+	startTimeKey contextKey = "start_time"
+)
+
+// ContextWithTraceID returns a copy of ctx carrying the trace ID
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// ContextWithUserID returns a copy of ctx carrying the user ID
+func ContextWithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// TraceIDFromContext returns the trace ID stored with ContextWithTraceID
+func TraceIDFromContext(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey).(string)
+	return traceID
+}
+
+// UserIDFromContext returns the user ID stored with ContextWithUserID
+func UserIDFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(userIDKey).(string)
+	return userID
+}
+
+// ContextWithStartTime returns a copy of ctx carrying the request start time
+func ContextWithStartTime(ctx context.Context, start time.Time) context.Context {
+	return context.WithValue(ctx, startTimeKey, start)
+}
+
+// StartTimeFromContext returns the start time stored with ContextWithStartTime
+func StartTimeFromContext(ctx context.Context) time.Time {
+	start, _ := ctx.Value(startTimeKey).(time.Time)
+	return start
+}
+
+// GoContextExtractor extracts trace and user IDs from a context.Context
+// Useful for non-HTTP call paths such as background workers
+func GoContextExtractor(req any) *Context {
+	if ctx, ok := req.(context.Context); ok {
+		return &Context{
+			UserID:    UserIDFromContext(ctx),
+			TraceID:   TraceIDFromContext(ctx),
+			StartTime: StartTimeFromContext(ctx),
+		}
+	}
+	return &Context{}
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
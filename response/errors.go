@@ -1,24 +1,199 @@
 // ==================== response/errors.go ====================
 package response
 
-import "net/http"
+import (
+	"errors"
+	"maps"
+	"net/http"
+	"runtime"
+	"sync"
+)
 
-// IsAppError checks if error is AppError
+// Sentinel errors for errors.Is checks, matched by Code
+// They are shared values: the With* setters return a modified copy instead of
+// changing them, e.g. ErrNotFound.WithContext("id", id)
+var (
+	ErrBadRequest      = &AppError{Code: ErrCodeInvalidInput, Message: "Bad request", HTTPStatus: http.StatusBadRequest, sentinel: true}
+	ErrUnauthorized    = &AppError{Code: ErrCodeUnauthorized, Message: "Unauthorized", HTTPStatus: http.StatusUnauthorized, sentinel: true}
+	ErrForbidden       = &AppError{Code: ErrCodeForbidden, Message: "Forbidden", HTTPStatus: http.StatusForbidden, sentinel: true}
+	ErrNotFound        = &AppError{Code: ErrCodeNotFound, Message: "Not found", HTTPStatus: http.StatusNotFound, sentinel: true}
+	ErrConflict        = &AppError{Code: ErrCodeConflict, Message: "Conflict", HTTPStatus: http.StatusConflict, sentinel: true}
+	ErrRequestTooLarge = &AppError{Code: ErrCodeRequestTooLarge, Message: "Request too large", HTTPStatus: http.StatusRequestEntityTooLarge, sentinel: true}
+	ErrTooManyRequests = &AppError{Code: ErrCodeTooManyRequest, Message: "Too many requests", HTTPStatus: http.StatusTooManyRequests, sentinel: true}
+	ErrValidation      = &AppError{Code: ErrCodeValidation, Message: "Validation failed", HTTPStatus: http.StatusUnprocessableEntity, sentinel: true}
+	ErrRequestCanceled = &AppError{Code: ErrCodeRequestCanceled, Message: "Request canceled", HTTPStatus: http.StatusRequestTimeout, sentinel: true}
+	ErrInternalServer  = &AppError{Code: ErrCodeInternalServer, Message: "Internal server error", HTTPStatus: http.StatusInternalServerError, sentinel: true}
+	ErrDatabase        = &AppError{Code: ErrCodeDatabaseError, Message: "Database error", HTTPStatus: http.StatusInternalServerError, sentinel: true}
+	ErrExternalService = &AppError{Code: ErrCodeExternalService, Message: "External service error", HTTPStatus: http.StatusInternalServerError, sentinel: true}
+	ErrTimeout         = &AppError{Code: ErrCodeTimeout, Message: "Request timed out", HTTPStatus: http.StatusGatewayTimeout, sentinel: true}
+)
+
+// defaultStatuses is the built-in HTTP status per error code
+var defaultStatuses = map[ErrorCode]int{
+	ErrCodeInvalidInput:    http.StatusBadRequest,
+	ErrCodeUnauthorized:    http.StatusUnauthorized,
+	ErrCodeForbidden:       http.StatusForbidden,
+	ErrCodeNotFound:        http.StatusNotFound,
+	ErrCodeConflict:        http.StatusConflict,
+	ErrCodeRequestTooLarge: http.StatusRequestEntityTooLarge,
+	ErrCodeTooManyRequest:  http.StatusTooManyRequests,
+	ErrCodeValidation:      http.StatusUnprocessableEntity,
+	ErrCodeRequestCanceled: http.StatusRequestTimeout,
+	ErrCodeInternalServer:  http.StatusInternalServerError,
+	ErrCodeDatabaseError:   http.StatusInternalServerError,
+	ErrCodeExternalService: http.StatusInternalServerError,
+	ErrCodeTimeout:         http.StatusGatewayTimeout,
+}
+
+var (
+	statusMapping   map[ErrorCode]int
+	statusMappingMu sync.RWMutex
+)
+
+// SetStatusMapping sets an org-wide HTTP status per error code, e.g.
+// ErrCodeConflict -> 422. It applies to errors handled with HTTPStatus 0;
+// the constructors keep setting their own status. Call it once at startup.
+func SetStatusMapping(mapping map[ErrorCode]int) {
+	statusMappingMu.Lock()
+	defer statusMappingMu.Unlock()
+	statusMapping = maps.Clone(mapping)
+}
+
+// StatusForCode returns the HTTP status for code from the custom mapping,
+// then the built-in one, defaulting to 500 for unknown codes
+func StatusForCode(code ErrorCode) int {
+	statusMappingMu.RLock()
+	status, ok := statusMapping[code]
+	statusMappingMu.RUnlock()
+	if ok {
+		return status
+	}
+
+	if status, ok := defaultStatuses[code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// sentinels lists every built-in error in declaration order
+var sentinels = []*AppError{
+	ErrBadRequest, ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict,
+	ErrRequestTooLarge, ErrTooManyRequests, ErrValidation, ErrRequestCanceled,
+	ErrInternalServer, ErrDatabase, ErrExternalService, ErrTimeout,
+}
+
+// ErrorCodes returns every built-in error code, e.g. to document the enum in an
+// OpenAPI spec
+func ErrorCodes() []ErrorCode {
+	codes := make([]ErrorCode, len(sentinels))
+	for i, sentinel := range sentinels {
+		codes[i] = sentinel.Code
+	}
+	return codes
+}
+
+// ExampleError returns the error envelope sent for code, for API docs
+// Validation errors include a sample field error.
+func ExampleError(code ErrorCode) ErrorResponse {
+	example := ErrorResponse{
+		Success: false,
+		Message: http.StatusText(StatusForCode(code)),
+		Code:    code,
+	}
+	for _, sentinel := range sentinels {
+		if sentinel.Code == code {
+			example.Message = sentinel.Message
+			break
+		}
+	}
+	if code == ErrCodeValidation {
+		example.Errors = map[string]any{"email": "must be a valid email address"}
+	}
+	return example
+}
+
+// IsAppError checks if error is AppError, looking through wrapped errors
 func IsAppError(err error) (*AppError, bool) {
-	if appErr, ok := err.(*AppError); ok {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
 		return appErr, true
 	}
 	return nil, false
 }
 
 // IsServerError checks if error is server error
+// Batch errors use their most severe status, see MultiError.HTTPStatus
 func IsServerError(err error) bool {
+	var multiErr *MultiError
+	if errors.As(err, &multiErr) && multiErr.Len() > 0 {
+		return multiErr.HTTPStatus() >= 500
+	}
 	if appErr, ok := IsAppError(err); ok {
 		return appErr.HTTPStatus >= 500
 	}
 	return false
 }
 
+// FieldErrorsOf retrieves field errors from an AppError anywhere in the error chain
+func FieldErrorsOf(err error) (FieldErrors, bool) {
+	var appErr *AppError
+	if !errors.As(err, &appErr) || appErr.Context == nil {
+		return nil, false
+	}
+
+	fields, ok := appErr.Context["errors"].(FieldErrors)
+	return fields, ok
+}
+
+// fieldErrorDetails converts the "errors" context entry into response details
+// Accepts FieldErrors, map[string]string and map[string]any; anything else is ignored
+func fieldErrorDetails(appErr *AppError) map[string]any {
+	if appErr.Context == nil {
+		return nil
+	}
+
+	switch details := appErr.Context["errors"].(type) {
+	case FieldErrors:
+		return stringMapToAny(details)
+	case map[string]string:
+		return stringMapToAny(details)
+	case map[string]any:
+		return details
+	default:
+		return nil
+	}
+}
+
+// stringMapToAny copies a string map into a map[string]any
+func stringMapToAny(m map[string]string) map[string]any {
+	result := make(map[string]any, len(m))
+	for key, value := range m {
+		result[key] = value
+	}
+	return result
+}
+
+// captureStack records the caller stack for server errors
+func captureStack() []uintptr {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs) // skip runtime.Callers, captureStack and the constructor
+	return pcs[:n]
+}
+
+// NewAppError creates an error with any code and HTTP status, e.g. a 418 or a
+// domain-specific 4xx. The constructors below are shortcuts over it.
+func NewAppError(code ErrorCode, httpStatus int, message string) *AppError {
+	appErr := &AppError{
+		Code:       code,
+		Message:    message,
+		HTTPStatus: httpStatus,
+	}
+	if httpStatus >= 500 {
+		appErr.stack = captureStack()
+	}
+	return appErr
+}
+
 // Error constructors
 func NewBadRequest(message string) *AppError {
 	return &AppError{
@@ -28,6 +203,16 @@ func NewBadRequest(message string) *AppError {
 	}
 }
 
+// NewValidationError creates a 422 Unprocessable Entity error carrying per-field messages
+func NewValidationError(message string, fields map[string]string) *AppError {
+	return &AppError{
+		Code:       ErrCodeValidation,
+		Message:    message,
+		HTTPStatus: http.StatusUnprocessableEntity,
+		Context:    map[string]any{"errors": FieldErrors(fields)},
+	}
+}
+
 func NewUnauthorized(message string) *AppError {
 	return &AppError{
 		Code:       ErrCodeUnauthorized,
@@ -76,12 +261,22 @@ func NewTooManyRequests(message string) *AppError {
 	}
 }
 
+// NewRequestCanceled creates a 408 error for requests the client gave up on
+func NewRequestCanceled(message string) *AppError {
+	return &AppError{
+		Code:       ErrCodeRequestCanceled,
+		Message:    message,
+		HTTPStatus: http.StatusRequestTimeout,
+	}
+}
+
 func NewInternalServerError(message string, err error) *AppError {
 	return &AppError{
 		Code:       ErrCodeInternalServer,
 		Message:    message,
 		HTTPStatus: http.StatusInternalServerError,
 		Err:        err,
+		stack:      captureStack(),
 	}
 }
 
@@ -91,6 +286,7 @@ func NewDatabaseError(message string, err error) *AppError {
 		Message:    message,
 		HTTPStatus: http.StatusInternalServerError,
 		Err:        err,
+		stack:      captureStack(),
 	}
 }
 
@@ -100,6 +296,18 @@ func NewExternalServiceError(message string, err error) *AppError {
 		Message:    message,
 		HTTPStatus: http.StatusInternalServerError,
 		Err:        err,
+		stack:      captureStack(),
+	}
+}
+
+// NewTimeout creates a 504 error for operations that ran out of time,
+// e.g. an upstream call exceeding its deadline
+func NewTimeout(message string) *AppError {
+	return &AppError{
+		Code:       ErrCodeTimeout,
+		Message:    message,
+		HTTPStatus: http.StatusGatewayTimeout,
+		stack:      captureStack(),
 	}
 }
 
@@ -108,6 +316,10 @@ func BadRequest(message string) error {
 	return NewBadRequest(message)
 }
 
+func ValidationError(message string, fields map[string]string) error {
+	return NewValidationError(message, fields)
+}
+
 func NotFound(message string) error {
 	return NewNotFound(message)
 }
@@ -131,3 +343,7 @@ func InternalServerError(message string, err error) error {
 func DatabaseError(message string, err error) error {
 	return NewDatabaseError(message, err)
 }
+
+func Timeout(message string) error {
+	return NewTimeout(message)
+}
@@ -0,0 +1,32 @@
+package response
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAppErrorIsSentinel(t *testing.T) {
+	err := fmt.Errorf("load user: %w", NewNotFound("User not found"))
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("errors.Is(wrapped NotFound, ErrNotFound) = false, want true")
+	}
+	if errors.Is(err, ErrConflict) {
+		t.Error("errors.Is(wrapped NotFound, ErrConflict) = true, want false")
+	}
+}
+
+func TestSentinelSettersReturnCopy(t *testing.T) {
+	err := ErrNotFound.WithContext("id", 1).WithHeader("X-Reason", "gone").WithStatus(410)
+
+	if ErrNotFound.Context != nil || ErrNotFound.Headers != nil || ErrNotFound.HTTPStatus != 404 {
+		t.Errorf("ErrNotFound was modified: %+v", ErrNotFound)
+	}
+	if err.Context["id"] != 1 || err.Headers["X-Reason"] != "gone" || err.HTTPStatus != 410 {
+		t.Errorf("copy = %+v, want context, header and status set", err)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("errors.Is(copy, ErrNotFound) = false, want true")
+	}
+}
@@ -2,18 +2,43 @@
 package response
 
 import (
+	"io"
+	"sync"
+
+	"github.com/fiqrioemry/go-api-toolkit/pagination"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
-// Global handler - initialized once
-var globalHandler *Handler
+// Global handler - set by InitGin, or lazily defaulted on first use
+var (
+	globalHandler *Handler
+	handlerMu     sync.RWMutex
+	defaultOnce   sync.Once
+	initialized   bool
+)
 
 // InitConfig for simple initialization
 type InitConfig struct {
 	Logger              *zap.Logger
 	LogSuccessResponses bool
 	LogErrorResponses   bool
+	ProblemDetails      bool // Render errors as RFC 7807 application/problem+json
+	IncludeStackTrace   bool // Log stack traces for panics and server errors
+	// Render XML when the Accept header prefers it
+	EnableContentNegotiation bool
+	// Hide 5xx messages from clients; the real message is still logged
+	ProductionMode bool
+	// Called for every 5xx error, e.g. to report it to Sentry
+	OnServerError ServerErrorHook
+	// Records status and error code of every response
+	Metrics MetricsObserver
+	// Transforms envelopes before writing, e.g. RawEncoder to drop the wrapper
+	Encoder ResponseEncoder
+	// AppError.Context values longer than this are truncated in logs (default 1024)
+	MaxLogContextValueLength int
+	// AppError.Context keys logged as "[REDACTED]", matched case-insensitively
+	RedactContextKeys []string
 }
 
 // GinJSONWriter implements JSONWriter for Gin framework
@@ -25,6 +50,33 @@ func (g *GinJSONWriter) JSON(statusCode int, obj any) {
 	g.ctx.JSON(statusCode, obj)
 }
 
+func (g *GinJSONWriter) XML(statusCode int, obj any) {
+	g.ctx.XML(statusCode, obj)
+}
+
+// Stream copies body to the response, returning read and write errors
+// (gin's DataFromReader only records them with c.Error)
+func (g *GinJSONWriter) Stream(statusCode int, contentType string, body io.Reader) error {
+	g.ctx.Header("Content-Type", contentType)
+	g.ctx.Status(statusCode)
+	_, err := io.Copy(g.ctx.Writer, body)
+	return err
+}
+
+func (g *GinJSONWriter) StreamChunks(statusCode int, contentType string, step func(w io.Writer) bool) {
+	g.ctx.Header("Content-Type", contentType)
+	g.ctx.Status(statusCode)
+	g.ctx.Stream(step)
+}
+
+func (g *GinJSONWriter) Status(statusCode int) {
+	g.ctx.Status(statusCode)
+}
+
+func (g *GinJSONWriter) SetHeader(key, value string) {
+	g.ctx.Header(key, value)
+}
+
 // GinContextExtractor extracts context from Gin request
 func GinContextExtractor(req any) *Context {
 	if ginCtx, ok := req.(*gin.Context); ok {
@@ -33,93 +85,190 @@ func GinContextExtractor(req any) *Context {
 			Method:    ginCtx.Request.Method,
 			ClientIP:  ginCtx.ClientIP(),
 			UserAgent: ginCtx.Request.UserAgent(),
-			UserID:    ginCtx.GetString("user_id"),
-			TraceID:   ginCtx.GetString("trace_id"),
+			UserID:    firstNonEmpty(ginCtx.GetString("user_id"), UserIDFromContext(ginCtx.Request.Context())),
+			TraceID:   firstNonEmpty(ginCtx.GetString("trace_id"), TraceIDFromContext(ginCtx.Request.Context())),
+			Accept:    ginCtx.GetHeader("Accept"),
+			StartTime: StartTimeFromContext(ginCtx.Request.Context()),
 		}
 	}
 	return &Context{}
 }
 
 // InitGin initializes the global response handler for Gin
+// Safe to call concurrently; the last call wins
 func InitGin(config InitConfig) {
-	logger := NewZapLogger(config.Logger)
+	var logger Logger = &NoOpLogger{}
+	if config.Logger != nil {
+		logger = NewZapLogger(config.Logger)
+	}
 
 	handlerConfig := &Config{
-		LogSuccessResponses: config.LogSuccessResponses,
-		LogErrorResponses:   config.LogErrorResponses,
-		LogLevel:            LogLevelInfo,
+		LogSuccessResponses:      config.LogSuccessResponses,
+		LogErrorResponses:        config.LogErrorResponses,
+		LogLevel:                 LogLevelInfo,
+		ProblemDetails:           config.ProblemDetails,
+		IncludeStackTrace:        config.IncludeStackTrace,
+		EnableContentNegotiation: config.EnableContentNegotiation,
+		ProductionMode:           config.ProductionMode,
+		MaxLogContextValueLength: config.MaxLogContextValueLength,
+		RedactContextKeys:        config.RedactContextKeys,
 	}
 
-	globalHandler = NewHandler(
+	handler := NewHandler(
 		WithLogger(logger),
 		WithContextExtractor(GinContextExtractor),
 		WithConfig(handlerConfig),
+		WithServerErrorHook(config.OnServerError),
+		WithMetricsObserver(config.Metrics),
+		WithEncoder(config.Encoder),
 	)
+
+	handlerMu.Lock()
+	defer handlerMu.Unlock()
+	globalHandler = handler
+	initialized = true
+}
+
+// IsInitialized reports whether InitGin has been called
+func IsInitialized() bool {
+	handlerMu.RLock()
+	defer handlerMu.RUnlock()
+	return initialized
+}
+
+// getHandler returns the global handler
+// Response functions called before InitGin degrade gracefully to a default
+// handler with a NoOp logger instead of panicking
+func getHandler() *Handler {
+	handlerMu.RLock()
+	handler := globalHandler
+	handlerMu.RUnlock()
+	if handler != nil {
+		return handler
+	}
+
+	defaultOnce.Do(func() {
+		handlerMu.Lock()
+		defer handlerMu.Unlock()
+		if globalHandler == nil {
+			globalHandler = NewHandler(WithContextExtractor(GinContextExtractor))
+		}
+	})
+
+	handlerMu.RLock()
+	defer handlerMu.RUnlock()
+	return globalHandler
 }
 
 // ============ RESPONSE FUNCTIONS ============
 
-func Error(c *gin.Context, err error) {
+func Error(c *gin.Context, err error, opts ...CallOption) {
 	writer := &GinJSONWriter{ctx: c}
-	globalHandler.HandleError(writer, c, err)
+	getHandler().HandleError(writer, c, err, opts...)
 }
 
-func OK(c *gin.Context, message string, data any) {
+func OK(c *gin.Context, message string, data any, opts ...CallOption) {
 	writer := &GinJSONWriter{ctx: c}
-	globalHandler.OK(writer, c, message, data)
+	getHandler().OK(writer, c, message, data, opts...)
 }
 
-func Created(c *gin.Context, message string, data any) {
+// OKTyped is OK with a typed data argument, for compile-time checks on the payload
+func OKTyped[T any](c *gin.Context, message string, data T, opts ...CallOption) {
+	OK(c, message, data, opts...)
+}
+
+func Created(c *gin.Context, message string, data any, opts ...CallOption) {
 	writer := &GinJSONWriter{ctx: c}
-	globalHandler.Created(writer, c, message, data)
+	getHandler().Created(writer, c, message, data, opts...)
 }
 
-func BadRequestMsg(c *gin.Context, message string) {
+func Accepted(c *gin.Context, message string, data any, opts ...CallOption) {
+	writer := &GinJSONWriter{ctx: c}
+	getHandler().Accepted(writer, c, message, data, opts...)
+}
+
+// Deleted sends 200 OK with "data": null, for DELETE endpoints
+func Deleted(c *gin.Context, message string, opts ...CallOption) {
+	writer := &GinJSONWriter{ctx: c}
+	getHandler().Deleted(writer, c, message, opts...)
+}
+
+func NoContent(c *gin.Context, opts ...CallOption) {
+	writer := &GinJSONWriter{ctx: c}
+	getHandler().NoContent(writer, c, opts...)
+}
+
+// File streams reader as a downloadable attachment, e.g. for CSV exports
+func File(c *gin.Context, filename string, contentType string, reader io.Reader, opts ...CallOption) error {
+	writer := &GinJSONWriter{ctx: c}
+	return getHandler().File(writer, c, filename, contentType, reader, opts...)
+}
+
+// Stream writes items as NDJSON until the channel is closed, e.g. for exports
+func Stream(c *gin.Context, items <-chan any, opts ...CallOption) error {
+	writer := &GinJSONWriter{ctx: c}
+	return getHandler().Stream(writer, c, items, opts...)
+}
+
+func BadRequestMsg(c *gin.Context, message string, opts ...CallOption) {
 	err := NewBadRequest(message)
-	Error(c, err)
+	Error(c, err, opts...)
 }
 
-func NotFoundMsg(c *gin.Context, message string) {
+func NotFoundMsg(c *gin.Context, message string, opts ...CallOption) {
 	err := NewNotFound(message)
-	Error(c, err)
+	Error(c, err, opts...)
 }
 
-func UnauthorizedMsg(c *gin.Context, message string) {
+func UnauthorizedMsg(c *gin.Context, message string, opts ...CallOption) {
 	err := NewUnauthorized(message)
-	Error(c, err)
+	Error(c, err, opts...)
 }
 
-func ForbiddenMsg(c *gin.Context, message string) {
+func ForbiddenMsg(c *gin.Context, message string, opts ...CallOption) {
 	err := NewForbidden(message)
-	Error(c, err)
+	Error(c, err, opts...)
 }
 
 // OKWithPagination sends success response with pagination
-func OKWithPagination(c *gin.Context, message string, data any, pagination any) {
+func OKWithPagination(c *gin.Context, message string, data any, pagination any, opts ...CallOption) {
+	writer := &GinJSONWriter{ctx: c}
+	getHandler().OKWithPagination(writer, c, message, data, pagination, opts...)
+}
+
+// OKWithPaginationLinks sends success response with pagination and links built from the request URL
+func OKWithPaginationLinks(c *gin.Context, message string, data any, pag *pagination.Pagination, opts ...CallOption) {
+	writer := &GinJSONWriter{ctx: c}
+	links := pag.Links(c.Request.URL.RequestURI())
+	getHandler().OKWithPaginationLinks(writer, c, message, data, pag, links, opts...)
+}
+
+// OKWithWarnings sends success response with non-fatal warnings in meta.warnings
+func OKWithWarnings(c *gin.Context, message string, data any, warnings []string, opts ...CallOption) {
 	writer := &GinJSONWriter{ctx: c}
-	globalHandler.OKWithPagination(writer, c, message, data, pagination)
+	getHandler().OKWithWarnings(writer, c, message, data, warnings, opts...)
 }
 
 // OKWithPermissions sends response with pagination and permissions
-func OKWithPermissions(c *gin.Context, message string, data any, permissions map[string]bool) {
+func OKWithPermissions(c *gin.Context, message string, data any, permissions map[string]bool, opts ...CallOption) {
 	writer := &GinJSONWriter{ctx: c}
-	globalHandler.OKWithPermissions(writer, c, message, data, permissions)
+	getHandler().OKWithPermissions(writer, c, message, data, permissions, opts...)
 }
 
 // OKWithPaginationAndPermissions sends response with pagination and permissions
-func OKWithPaginationAndPermissions(c *gin.Context, message string, data any, pagination any, permissions map[string]bool) {
+func OKWithPaginationAndPermissions(c *gin.Context, message string, data any, pagination any, permissions map[string]bool, opts ...CallOption) {
+	writer := &GinJSONWriter{ctx: c}
+	getHandler().OKWithPaginationAndPermissions(writer, c, message, data, pagination, permissions, opts...)
+}
+
+// OKList sends success response with items and pagination built from params and total
+func OKList(c *gin.Context, message string, items any, total int, params pagination.DefaultQueryParams, opts ...CallOption) {
 	writer := &GinJSONWriter{ctx: c}
-	globalHandler.OKWithPaginationAndPermissions(writer, c, message, data, pagination, permissions)
+	getHandler().OKList(writer, c, message, items, total, params, opts...)
 }
 
 // PaginatedResponse creates paginated response (convenience function)
-func PaginatedResponse(c *gin.Context, message string, data any, page, limit, total int) {
-	pagination := map[string]any{
-		"page":       page,
-		"limit":      limit,
-		"totalItems": total,
-		"totalPages": (total + limit - 1) / limit,
-		"offset":     (page - 1) * limit,
-	}
-	OKWithPagination(c, message, data, pagination)
+// Uses pagination.Build so defaults and limit clamping match the pagination package
+func PaginatedResponse(c *gin.Context, message string, data any, page, limit, total int, opts ...CallOption) {
+	OKWithPagination(c, message, data, pagination.Build(page, limit, total), opts...)
 }
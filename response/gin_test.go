@@ -0,0 +1,102 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fiqrioemry/go-api-toolkit/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+func TestPaginatedResponseMatchesBuild(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cases := []struct{ page, limit, total int }{
+		{1, 10, 95},
+		{3, 10, 95},
+		{0, 0, 5},      // defaults
+		{-2, -5, 5},    // negative input
+		{1, 101, 250},  // just above MaxLimit
+		{2, 1000, 250}, // far above MaxLimit
+		{2, 10, 0},     // empty results
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("page=%d,limit=%d,total=%d", tc.page, tc.limit, tc.total), func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			c.Request = httptest.NewRequest(http.MethodGet, "/items", nil)
+
+			PaginatedResponse(c, "ok", []int{}, tc.page, tc.limit, tc.total)
+
+			var body struct {
+				Meta struct {
+					Pagination json.RawMessage `json:"pagination"`
+				} `json:"meta"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			want, err := json.Marshal(pagination.Build(tc.page, tc.limit, tc.total))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(body.Meta.Pagination, want) {
+				t.Errorf("meta pagination = %s, want %s", body.Meta.Pagination, want)
+			}
+
+			var meta pagination.Pagination
+			if err := json.Unmarshal(body.Meta.Pagination, &meta); err != nil {
+				t.Fatal(err)
+			}
+			if tc.limit > 100 && meta.Limit != 100 {
+				t.Errorf("limit = %d, want clamped to 100", meta.Limit)
+			}
+		})
+	}
+}
+
+func TestInitGinPassesLogContextSettings(t *testing.T) {
+	InitGin(InitConfig{MaxLogContextValueLength: 256, RedactContextKeys: []string{"email"}})
+	defer InitGin(InitConfig{})
+
+	config := getHandler().config
+	if config.MaxLogContextValueLength != 256 || len(config.RedactContextKeys) != 1 || config.RedactContextKeys[0] != "email" {
+		t.Errorf("config = %+v, want log context settings passed through", config)
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("disk read failed")
+}
+
+func TestFileReturnsCopyError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/report.csv", nil)
+
+	err := File(c, "report.csv", "text/csv", failingReader{})
+	if err == nil || err.Error() != "disk read failed" {
+		t.Errorf("File error = %v, want the read error", err)
+	}
+
+	rec = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/report.csv", nil)
+
+	if err := File(c, "report.csv", "text/csv", strings.NewReader("a,b\n")); err != nil {
+		t.Fatalf("File error = %v", err)
+	}
+	if rec.Body.String() != "a,b\n" || rec.Header().Get("Content-Type") != "text/csv" {
+		t.Errorf("got %q with Content-Type %q", rec.Body.String(), rec.Header().Get("Content-Type"))
+	}
+}
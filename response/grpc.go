@@ -0,0 +1,100 @@
+//go:build grpc
+
+// ==================== response/grpc.go ====================
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Build with -tags grpc to make *AppError usable as a gRPC error.
+// status.FromError and status.Code pick up GRPCStatus automatically, so the
+// same error returned from a service can be sent by REST and gRPC handlers.
+
+// grpcCodes maps error codes to gRPC status codes
+var grpcCodes = map[ErrorCode]codes.Code{
+	ErrCodeInvalidInput:    codes.InvalidArgument,
+	ErrCodeValidation:      codes.InvalidArgument,
+	ErrCodeUnauthorized:    codes.Unauthenticated,
+	ErrCodeForbidden:       codes.PermissionDenied,
+	ErrCodeNotFound:        codes.NotFound,
+	ErrCodeConflict:        codes.AlreadyExists,
+	ErrCodeRequestTooLarge: codes.ResourceExhausted,
+	ErrCodeTooManyRequest:  codes.ResourceExhausted,
+	ErrCodeInternalServer:  codes.Internal,
+	ErrCodeDatabaseError:   codes.Internal,
+	ErrCodeExternalService: codes.Unavailable,
+	ErrCodeTimeout:         codes.DeadlineExceeded,
+	ErrCodeRequestCanceled: codes.Canceled,
+}
+
+// GRPCCode returns the gRPC status code for the error
+// Unknown codes fall back to a mapping based on HTTPStatus
+func (e *AppError) GRPCCode() codes.Code {
+	if code, ok := grpcCodes[e.Code]; ok {
+		return code
+	}
+
+	switch e.HTTPStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusRequestTimeout:
+		return codes.Canceled
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	if e.HTTPStatus >= 500 {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// GRPCStatus converts the error to a gRPC status
+// Field errors are attached as a BadRequest detail with one FieldViolation per field
+func (e *AppError) GRPCStatus() *status.Status {
+	st := status.New(e.GRPCCode(), e.Message)
+
+	fields := fieldErrorDetails(e)
+	if len(fields) == 0 {
+		return st
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(names))
+	for _, name := range names {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       name,
+			Description: fmt.Sprint(fields[name]),
+		})
+	}
+
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		return detailed
+	}
+	return st
+}
@@ -1,13 +1,34 @@
 // ==================== response/handler.go ====================
 package response
 
-import "net/http"
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fiqrioemry/go-api-toolkit/pagination"
+)
 
 // Handler handles HTTP responses with logging
 type Handler struct {
 	logger           Logger
 	contextExtractor ContextExtractor
 	config           *Config
+	onServerError    ServerErrorHook
+	metrics          MetricsObserver
+	encoder          ResponseEncoder
 }
 
 // Config represents handler configuration
@@ -16,6 +37,16 @@ type Config struct {
 	LogErrorResponses   bool
 	LogLevel            LogLevel
 	IncludeStackTrace   bool
+	ProblemDetails      bool   // Render errors as RFC 7807 application/problem+json
+	ProblemTypeBaseURI  string // Prefix for the problem "type" member, defaults to "urn:problem-type:"
+	// Render XML when the Accept header prefers it; JSON stays the default
+	EnableContentNegotiation bool
+	// Hide 5xx messages from clients; the real message is still logged
+	ProductionMode bool
+	// AppError.Context values longer than this are truncated in logs (default 1024)
+	MaxLogContextValueLength int
+	// AppError.Context keys logged as "[REDACTED]", matched case-insensitively
+	RedactContextKeys []string
 }
 
 // DefaultConfig returns default configuration
@@ -33,6 +64,44 @@ type JSONWriter interface {
 	JSON(statusCode int, obj any)
 }
 
+// StatusWriter is implemented by writers that can send a status without a body
+type StatusWriter interface {
+	Status(statusCode int)
+}
+
+// XMLWriter is implemented by writers that can render XML
+// Used when Config.EnableContentNegotiation is on and the client prefers XML
+type XMLWriter interface {
+	XML(statusCode int, obj any)
+}
+
+// StreamWriter is implemented by writers that can stream a raw body
+type StreamWriter interface {
+	Stream(statusCode int, contentType string, body io.Reader) error
+}
+
+// ChunkWriter is implemented by writers that can stream a body piece by piece
+// step is called repeatedly and the output flushed after each call, until it
+// returns false or the client goes away
+type ChunkWriter interface {
+	StreamChunks(statusCode int, contentType string, step func(w io.Writer) bool)
+}
+
+// NDJSONContentType is the media type for newline-delimited JSON streams
+const NDJSONContentType = "application/x-ndjson"
+
+// ndjsonFlushBatch is the maximum number of buffered items written between flushes
+const ndjsonFlushBatch = 100
+
+// ErrStreamingNotSupported is returned when the writer can't stream raw bodies
+var ErrStreamingNotSupported = errors.New("response writer does not support streaming")
+
+// HeaderWriter is implemented by writers that can set response headers
+// Headers must be set before JSON is called
+type HeaderWriter interface {
+	SetHeader(key, value string)
+}
+
 // NewHandler creates a new response handler
 func NewHandler(options ...Option) *Handler {
 	h := &Handler{
@@ -71,28 +140,154 @@ func WithConfig(config *Config) Option {
 	}
 }
 
+// ServerErrorHook is called for every 5xx error handled, e.g. to forward it
+// to Sentry or Rollbar. err includes the underlying Err and stack.
+type ServerErrorHook func(ctx *Context, err *AppError)
+
+// WithServerErrorHook sets a callback fired for server errors (5xx) only
+// The hook runs regardless of logging settings; panics inside it are recovered and logged
+func WithServerErrorHook(hook ServerErrorHook) Option {
+	return func(h *Handler) {
+		h.onServerError = hook
+	}
+}
+
+// MetricsObserver records every response sent by the handler, e.g. for RED metrics
+// code is empty for successful responses
+type MetricsObserver interface {
+	Observe(path, method string, status int, code ErrorCode)
+}
+
+// DurationObserver is implemented by MetricsObservers that also record latency
+// It is called in addition to Observe when the request start time is known,
+// i.e. when MetricsMiddleware (or ContextWithStartTime) is in use.
+type DurationObserver interface {
+	ObserveDuration(path, method string, status int, code ErrorCode, duration time.Duration)
+}
+
+// WithMetricsObserver sets the observer called for every success and error response
+func WithMetricsObserver(observer MetricsObserver) Option {
+	return func(h *Handler) {
+		h.metrics = observer
+	}
+}
+
+// ResponseEncoder transforms the envelope (SuccessResponse, ErrorResponse or
+// MultiErrorResponse) before it is written, to match an existing API contract.
+// Problem details are written as-is.
+type ResponseEncoder interface {
+	Encode(statusCode int, envelope any) any
+}
+
+// EncoderFunc adapts a function to ResponseEncoder
+type EncoderFunc func(statusCode int, envelope any) any
+
+func (f EncoderFunc) Encode(statusCode int, envelope any) any {
+	return f(statusCode, envelope)
+}
+
+// RawEncoder sends the data of success responses without the envelope
+// Error responses keep the standard envelope
+type RawEncoder struct{}
+
+func (RawEncoder) Encode(statusCode int, envelope any) any {
+	if success, ok := envelope.(SuccessResponse); ok {
+		return success.Data
+	}
+	return envelope
+}
+
+// WithEncoder sets the encoder applied to every envelope
+func WithEncoder(encoder ResponseEncoder) Option {
+	return func(h *Handler) {
+		h.encoder = encoder
+	}
+}
+
+// CallOption overrides handler behavior for a single response
+type CallOption func(*callOptions)
+
+// callOptions holds the effective settings for one response
+type callOptions struct {
+	logSuccess bool
+	logError   bool
+}
+
+// WithoutLog suppresses logging for this response, e.g. for health checks
+func WithoutLog() CallOption {
+	return func(o *callOptions) {
+		o.logSuccess = false
+		o.logError = false
+	}
+}
+
+// WithLog forces logging for this response, even when disabled in Config
+func WithLog() CallOption {
+	return func(o *callOptions) {
+		o.logSuccess = true
+		o.logError = true
+	}
+}
+
+// callConfig applies per-call options on top of the handler configuration
+func (h *Handler) callConfig(opts []CallOption) callOptions {
+	call := callOptions{
+		logSuccess: h.config.LogSuccessResponses,
+		logError:   h.config.LogErrorResponses,
+	}
+	for _, opt := range opts {
+		opt(&call)
+	}
+	return call
+}
+
 // HandleError handles error responses
-func (h *Handler) HandleError(w JSONWriter, req any, err error) {
-	ctx := h.extractContext(req)
+func (h *Handler) HandleError(w JSONWriter, req any, err error, opts ...CallOption) {
+	h.handleError(w, h.extractContext(req), err, h.callConfig(opts))
+}
 
-	if appErr, ok := IsAppError(err); ok {
+// HandleErrorCtx handles errors outside of an HTTP request, e.g. in background
+// workers. Trace and user IDs are taken from ctx (see ContextWithTraceID).
+// w may be nil, in which case the error is only logged and reported.
+func (h *Handler) HandleErrorCtx(ctx context.Context, w JSONWriter, err error, opts ...CallOption) {
+	h.handleError(w, GoContextExtractor(ctx), err, h.callConfig(opts))
+}
+
+// handleError logs, reports and writes err
+func (h *Handler) handleError(w JSONWriter, ctx *Context, err error, call callOptions) {
+	// Check batch errors first: errors.As would otherwise match their first item
+	// An empty batch is a bug (use ErrorOrNil) and is handled as an unknown error
+	var multiErr *MultiError
+	if errors.As(err, &multiErr) && multiErr.Len() > 0 {
+		h.handleMultiError(w, ctx, multiErr, call)
+		return
+	}
+
+	if appErr, ok := IsAppError(normalizeError(err)); ok {
 		response := ErrorResponse{
 			Success: false,
 			Message: appErr.Message,
 			Code:    appErr.Code,
+			Errors:  fieldErrorDetails(appErr),
 		}
 
-		if appErr.Context != nil {
-			if errorDetails, exists := appErr.Context["errors"]; exists {
-				response.Errors = errorDetails.(map[string]any)
-			}
+		if h.config.ProductionMode && IsServerError(appErr) {
+			response.Message = "Internal server error"
 		}
 
-		if h.config.LogErrorResponses {
+		if call.logError {
 			h.logError(ctx, appErr)
 		}
+		h.reportServerError(ctx, appErr)
+		h.observe(ctx, appErr.HTTPStatus, appErr.Code)
+
+		setHeaders(w, appErr.Headers)
+		if appErr.RetryAfter > 0 {
+			seconds := int(math.Ceil(appErr.RetryAfter.Seconds()))
+			setHeader(w, "Retry-After", strconv.Itoa(seconds))
+		}
 
-		w.JSON(appErr.HTTPStatus, response)
+		h.writeError(w, ctx, appErr.HTTPStatus, response)
 		return
 	}
 
@@ -103,31 +298,217 @@ func (h *Handler) HandleError(w JSONWriter, req any, err error) {
 		Code:    ErrCodeInternalServer,
 	}
 
-	if h.config.LogErrorResponses {
+	if call.logError {
 		h.logUnknownError(ctx, err)
 	}
+	h.reportServerError(ctx, NewInternalServerError("Unknown error occurred", err))
+	h.observe(ctx, http.StatusInternalServerError, ErrCodeInternalServer)
 
-	w.JSON(http.StatusInternalServerError, response)
+	h.writeError(w, ctx, http.StatusInternalServerError, response)
 }
 
-// Success sends success response
-func (h *Handler) Success(w JSONWriter, req any, statusCode int, message string, data any) {
-	response := SuccessResponse{
-		Success: true,
-		Message: message,
-		Data:    data,
+// paginationErrors are the pagination sentinels rendered as 400 Bad Request
+var paginationErrors = []error{
+	pagination.ErrLimitExceeded,
+	pagination.ErrInvalidSort,
+	pagination.ErrInvalidCursor,
+	pagination.ErrInvalidRange,
+	pagination.ErrConflictingPagination,
+}
+
+// normalizeError maps well-known standard library and pagination errors to AppErrors
+// Errors that already carry an AppError are left untouched, except that a
+// missing HTTPStatus is resolved with StatusForCode
+func normalizeError(err error) error {
+	if appErr, ok := IsAppError(err); ok {
+		if appErr.HTTPStatus == 0 {
+			return resolveStatus(appErr)
+		}
+		return err
 	}
 
-	if h.config.LogSuccessResponses {
-		ctx := h.extractContext(req)
-		h.logSuccess(ctx, statusCode, message)
+	// Rejected pagination parameters are client errors
+	if slices.ContainsFunc(paginationErrors, func(target error) bool { return errors.Is(err, target) }) {
+		badRequest := NewBadRequest(err.Error())
+		badRequest.Err = err
+		return badRequest
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return NewRequestTooLarge(fmt.Sprintf("Request body exceeds %d bytes", maxBytesErr.Limit))
+	}
+
+	// Deadlines are server-side timeouts; cancellation means the client went away
+	if errors.Is(err, context.DeadlineExceeded) {
+		timeout := NewTimeout("Request timed out")
+		timeout.Err = err
+		return timeout
+	}
+	if errors.Is(err, context.Canceled) {
+		canceled := NewRequestCanceled("Request canceled")
+		canceled.Err = err
+		return canceled
+	}
+
+	return err
+}
+
+// resolveStatus returns appErr, or a copy with HTTPStatus set from
+// StatusForCode when it is missing
+func resolveStatus(appErr *AppError) *AppError {
+	if appErr.HTTPStatus != 0 {
+		return appErr
+	}
+	resolved := *appErr
+	resolved.HTTPStatus = StatusForCode(appErr.Code)
+	return &resolved
+}
+
+// handleMultiError renders every failed batch item with the most severe status
+func (h *Handler) handleMultiError(w JSONWriter, ctx *Context, multiErr *MultiError, call callOptions) {
+	statusCode := multiErr.HTTPStatus()
+
+	response := MultiErrorResponse{
+		Success: false,
+		Message: "One or more items failed",
+		Errors:  make([]ItemError, 0, multiErr.Len()),
+	}
+	for _, item := range multiErr.items {
+		message := item.err.Message
+		if h.config.ProductionMode && IsServerError(item.err) {
+			message = "Internal server error"
+		}
+		response.Errors = append(response.Errors, ItemError{
+			Index:   item.index,
+			Code:    item.err.Code,
+			Message: message,
+		})
+	}
+
+	if call.logError {
+		h.logMultiError(ctx, statusCode, multiErr)
+	}
+	for _, item := range multiErr.items {
+		h.reportServerError(ctx, item.err)
+	}
+	h.observe(ctx, statusCode, multiErr.code())
+
+	if w != nil && h.config.ProblemDetails {
+		problem := h.problemDetails(ctx, statusCode, ErrorResponse{Message: response.Message, Code: multiErr.code()})
+		setHeader(w, "Content-Type", ProblemContentType)
+		w.JSON(statusCode, batchProblemDetails{ProblemDetails: problem, Errors: response.Errors})
+		return
+	}
+	h.write(w, ctx, statusCode, response)
+}
+
+// HandlePanic logs a recovered panic and sends a 500 response
+// Panics are always logged, regardless of LogErrorResponses
+// A nil w only logs and reports the panic, e.g. once the response has started
+func (h *Handler) HandlePanic(w JSONWriter, req any, recovered any, stack []byte) {
+	ctx := h.extractContext(req)
+
+	fields := h.buildLogFields(ctx)
+	fields = append(fields, LogField{Key: "panic", Value: fmt.Sprint(recovered)})
+	if h.config.IncludeStackTrace {
+		fields = append(fields, LogField{Key: "stacktrace", Value: string(stack)})
+	}
+	h.logger.Error("Panic recovered", fields...)
+	h.reportServerError(ctx, NewInternalServerError("Panic recovered", fmt.Errorf("panic: %v", recovered)))
+	h.observe(ctx, http.StatusInternalServerError, ErrCodeInternalServer)
+
+	response := ErrorResponse{
+		Success: false,
+		Message: "Internal server error",
+		Code:    ErrCodeInternalServer,
+	}
+	h.writeError(w, ctx, http.StatusInternalServerError, response)
+}
+
+// writeError writes the error envelope, or problem details when enabled
+func (h *Handler) writeError(w JSONWriter, ctx *Context, statusCode int, response ErrorResponse) {
+	if w == nil {
+		return
+	}
+	if h.config.ProblemDetails {
+		setHeader(w, "Content-Type", ProblemContentType)
+		w.JSON(statusCode, h.problemDetails(ctx, statusCode, response))
+		return
+	}
+
+	h.write(w, ctx, statusCode, response)
+}
+
+// write renders the envelope as JSON, or as XML when negotiated
+func (h *Handler) write(w JSONWriter, ctx *Context, statusCode int, obj any) {
+	if w == nil {
+		return
+	}
+	if h.encoder != nil {
+		obj = h.encoder.Encode(statusCode, obj)
+	}
+
+	// Payloads encoding/xml can't marshal fall back to JSON instead of an empty body
+	if h.config.EnableContentNegotiation && prefersXML(ctx.Accept) {
+		if xw, ok := w.(XMLWriter); ok {
+			if _, err := xml.Marshal(obj); err == nil {
+				xw.XML(statusCode, obj)
+				return
+			}
+		}
 	}
 
-	w.JSON(statusCode, response)
+	w.JSON(statusCode, obj)
+}
+
+// problemDetails converts an error envelope into RFC 7807 problem details
+func (h *Handler) problemDetails(ctx *Context, statusCode int, response ErrorResponse) ProblemDetails {
+	baseURI := h.config.ProblemTypeBaseURI
+	if baseURI == "" {
+		baseURI = "urn:problem-type:"
+	}
+
+	return ProblemDetails{
+		Type:     baseURI + strings.ToLower(strings.ReplaceAll(string(response.Code), "_", "-")),
+		Title:    http.StatusText(statusCode),
+		Status:   statusCode,
+		Detail:   response.Message,
+		Instance: ctx.Path,
+		Errors:   response.Errors,
+	}
+}
+
+// setHeader sets a response header when the writer supports it
+func setHeader(w JSONWriter, key, value string) {
+	if hw, ok := w.(HeaderWriter); ok {
+		hw.SetHeader(key, value)
+	}
+}
+
+// setHeaders sets response headers when the writer supports it
+func setHeaders(w JSONWriter, headers map[string]string) {
+	if hw, ok := w.(HeaderWriter); ok {
+		for key, value := range headers {
+			hw.SetHeader(key, value)
+		}
+	}
+}
+
+// Success sends success response
+func (h *Handler) Success(w JSONWriter, req any, statusCode int, message string, data any, opts ...CallOption) {
+	h.SuccessWithMeta(w, req, statusCode, message, data, nil, opts...)
+}
+
+// SuccessWithHeaders sends success response with extra response headers
+// Headers are ignored when the writer doesn't implement HeaderWriter
+func (h *Handler) SuccessWithHeaders(w JSONWriter, req any, statusCode int, message string, data any, headers map[string]string, opts ...CallOption) {
+	setHeaders(w, headers)
+	h.Success(w, req, statusCode, message, data, opts...)
 }
 
 // SuccessWithMeta sends success response with metadata
-func (h *Handler) SuccessWithMeta(w JSONWriter, req any, statusCode int, message string, data any, meta *Meta) {
+func (h *Handler) SuccessWithMeta(w JSONWriter, req any, statusCode int, message string, data any, meta *Meta, opts ...CallOption) {
 	response := SuccessResponse{
 		Success: true,
 		Message: message,
@@ -135,44 +516,166 @@ func (h *Handler) SuccessWithMeta(w JSONWriter, req any, statusCode int, message
 		Meta:    meta,
 	}
 
-	if h.config.LogSuccessResponses {
-		ctx := h.extractContext(req)
+	ctx := h.extractContext(req)
+	if h.callConfig(opts).logSuccess {
 		h.logSuccess(ctx, statusCode, message)
 	}
+	h.observe(ctx, statusCode, "")
 
-	w.JSON(statusCode, response)
+	h.write(w, ctx, statusCode, response)
 }
 
 // OK sends 200 OK response
-func (h *Handler) OK(w JSONWriter, req any, message string, data any) {
-	h.Success(w, req, http.StatusOK, message, data)
+func (h *Handler) OK(w JSONWriter, req any, message string, data any, opts ...CallOption) {
+	h.Success(w, req, http.StatusOK, message, data, opts...)
 }
 
 // Created sends 201 Created response
-func (h *Handler) Created(w JSONWriter, req any, message string, data any) {
-	h.Success(w, req, http.StatusCreated, message, data)
+func (h *Handler) Created(w JSONWriter, req any, message string, data any, opts ...CallOption) {
+	h.Success(w, req, http.StatusCreated, message, data, opts...)
+}
+
+// Accepted sends 202 Accepted response
+func (h *Handler) Accepted(w JSONWriter, req any, message string, data any, opts ...CallOption) {
+	h.Success(w, req, http.StatusAccepted, message, data, opts...)
+}
+
+// Deleted sends 200 OK response with an explicit "data": null, for DELETE endpoints
+// Use NoContent to reply 204 without a body instead
+func (h *Handler) Deleted(w JSONWriter, req any, message string, opts ...CallOption) {
+	h.Success(w, req, http.StatusOK, message, nullData{}, opts...)
+}
+
+// NoContent sends 204 No Content response with an empty body
+func (h *Handler) NoContent(w JSONWriter, req any, opts ...CallOption) {
+	ctx := h.extractContext(req)
+	if h.callConfig(opts).logSuccess {
+		h.logSuccess(ctx, http.StatusNoContent, "")
+	}
+	h.observe(ctx, http.StatusNoContent, "")
+
+	if sw, ok := w.(StatusWriter); ok {
+		sw.Status(http.StatusNoContent)
+		return
+	}
+	// Writers without StatusWriter must not render a body for 204
+	w.JSON(http.StatusNoContent, nil)
+}
+
+// File streams reader as a downloadable attachment
+// Returns ErrStreamingNotSupported if the writer doesn't implement StreamWriter
+func (h *Handler) File(w JSONWriter, req any, filename string, contentType string, reader io.Reader, opts ...CallOption) error {
+	sw, ok := w.(StreamWriter)
+	if !ok {
+		return ErrStreamingNotSupported
+	}
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	setHeader(w, "Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+
+	ctx := h.extractContext(req)
+	if h.callConfig(opts).logSuccess {
+		h.logSuccess(ctx, http.StatusOK, "File download: "+filename)
+	}
+	h.observe(ctx, http.StatusOK, "")
+
+	return sw.Stream(http.StatusOK, contentType, reader)
+}
+
+// Stream writes items as NDJSON (one JSON document per line) until the channel
+// is closed, flushing whenever the producer is idle or after every 100 items.
+// Returns ErrStreamingNotSupported if the writer doesn't implement ChunkWriter.
+// The stream stops early when the client disconnects, so producers should
+// stop sending when the request context is done.
+func (h *Handler) Stream(w JSONWriter, req any, items <-chan any, opts ...CallOption) error {
+	cw, ok := w.(ChunkWriter)
+	if !ok {
+		return ErrStreamingNotSupported
+	}
+
+	ctx := h.extractContext(req)
+	if h.callConfig(opts).logSuccess {
+		h.logSuccess(ctx, http.StatusOK, "NDJSON stream")
+	}
+	h.observe(ctx, http.StatusOK, "")
+
+	var encodeErr error
+	cw.StreamChunks(http.StatusOK, NDJSONContentType, func(out io.Writer) bool {
+		item, ok := <-items
+		if !ok {
+			return false
+		}
+
+		encoder := json.NewEncoder(out)
+		for written := 1; ; written++ {
+			if encodeErr = encoder.Encode(item); encodeErr != nil {
+				return false
+			}
+			if written == ndjsonFlushBatch {
+				return true
+			}
+
+			// Keep writing while items are ready, flush once the producer is idle
+			select {
+			case item, ok = <-items:
+				if !ok {
+					return false
+				}
+			default:
+				return true
+			}
+		}
+	})
+
+	return encodeErr
 }
 
 // OKWithPagination sends 200 OK response with pagination
-func (h *Handler) OKWithPagination(w JSONWriter, req any, message string, data any, pagination any) {
+func (h *Handler) OKWithPagination(w JSONWriter, req any, message string, data any, pagination any, opts ...CallOption) {
 	h.SuccessWithMeta(w, req, http.StatusOK, message, data, &Meta{
 		Pagination: pagination,
-	})
+	}, opts...)
+}
+
+// OKList sends 200 OK response with items and pagination built from params and total
+// params are defaulted and clamped the same way as pagination.Quick
+func (h *Handler) OKList(w JSONWriter, req any, message string, items any, total int, params pagination.DefaultQueryParams, opts ...CallOption) {
+	h.OKWithPagination(w, req, message, items, pagination.Quick(params, total), opts...)
+}
+
+// OKWithPaginationLinks sends 200 OK response with pagination and navigation links
+func (h *Handler) OKWithPaginationLinks(w JSONWriter, req any, message string, data any, pagination any, links map[string]string, opts ...CallOption) {
+	h.SuccessWithMeta(w, req, http.StatusOK, message, data, &Meta{
+		Pagination: pagination,
+		Links:      links,
+	}, opts...)
+}
+
+// OKWithWarnings sends 200 OK response with non-fatal warnings in meta.warnings
+// e.g. when the operation succeeded but optional enrichment failed
+func (h *Handler) OKWithWarnings(w JSONWriter, req any, message string, data any, warnings []string, opts ...CallOption) {
+	var meta *Meta
+	if len(warnings) > 0 {
+		meta = &Meta{Warnings: warnings}
+	}
+	h.SuccessWithMeta(w, req, http.StatusOK, message, data, meta, opts...)
 }
 
 // OKWithPermissions sends 200 ok response with permissions
-func (h *Handler) OKWithPermissions(w JSONWriter, req any, message string, data any, permissions map[string]bool) {
+func (h *Handler) OKWithPermissions(w JSONWriter, req any, message string, data any, permissions map[string]bool, opts ...CallOption) {
 	h.SuccessWithMeta(w, req, http.StatusOK, message, data, &Meta{
 		Permissions: permissions,
-	})
+	}, opts...)
 }
 
 // OKWithPaginationAndPermissions sends 200 OK response with pagination and permissions
-func (h *Handler) OKWithPaginationAndPermissions(w JSONWriter, req any, message string, data any, pagination any, permissions map[string]bool) {
+func (h *Handler) OKWithPaginationAndPermissions(w JSONWriter, req any, message string, data any, pagination any, permissions map[string]bool, opts ...CallOption) {
 	h.SuccessWithMeta(w, req, http.StatusOK, message, data, &Meta{
 		Pagination:  pagination,
 		Permissions: permissions,
-	})
+	}, opts...)
 }
 
 // extractContext extracts context from request
@@ -183,6 +686,35 @@ func (h *Handler) extractContext(req any) *Context {
 	return &Context{}
 }
 
+// observe reports the response to the metrics observer, if any
+func (h *Handler) observe(ctx *Context, statusCode int, code ErrorCode) {
+	if h.metrics == nil {
+		return
+	}
+	h.metrics.Observe(ctx.Path, ctx.Method, statusCode, code)
+	if do, ok := h.metrics.(DurationObserver); ok && !ctx.StartTime.IsZero() {
+		do.ObserveDuration(ctx.Path, ctx.Method, statusCode, code, time.Since(ctx.StartTime))
+	}
+}
+
+// reportServerError passes server errors to the configured hook
+// A panicking hook must not break the response, so it is recovered and logged
+func (h *Handler) reportServerError(ctx *Context, appErr *AppError) {
+	if h.onServerError == nil || !IsServerError(appErr) {
+		return
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			fields := h.buildLogFields(ctx)
+			fields = append(fields, LogField{Key: "panic", Value: fmt.Sprint(recovered)})
+			h.logger.Error("Server error hook panicked", fields...)
+		}
+	}()
+
+	h.onServerError(ctx, appErr)
+}
+
 // logError logs application errors
 func (h *Handler) logError(ctx *Context, appErr *AppError) {
 	fields := h.buildLogFields(ctx)
@@ -190,21 +722,99 @@ func (h *Handler) logError(ctx *Context, appErr *AppError) {
 		LogField{Key: "error_code", Value: string(appErr.Code)},
 		LogField{Key: "error_message", Value: appErr.Message},
 	)
+	fields = append(fields, h.contextLogFields(appErr)...)
 
 	if IsServerError(appErr) {
 		if appErr.Err != nil {
 			fields = append(fields, LogField{Key: "underlying_error", Value: appErr.Err.Error()})
 		}
+		if h.config.IncludeStackTrace {
+			if stack := appErr.StackTrace(); stack != "" {
+				fields = append(fields, LogField{Key: "stacktrace", Value: stack})
+			}
+		}
 		h.logger.Error("Server error occurred", fields...)
 	} else {
 		h.logger.Warn("Client error occurred", fields...)
 	}
 }
 
+// defaultMaxLogContextValueLength caps logged context values when Config doesn't
+const defaultMaxLogContextValueLength = 1024
+
+// contextLogFields converts AppError.Context into log fields, sorted by key
+// The "errors" key is skipped because it holds field errors sent to the client.
+// Redacted keys are masked and long values are truncated.
+func (h *Handler) contextLogFields(appErr *AppError) []LogField {
+	if len(appErr.Context) == 0 {
+		return nil
+	}
+
+	maxLength := h.config.MaxLogContextValueLength
+	if maxLength <= 0 {
+		maxLength = defaultMaxLogContextValueLength
+	}
+
+	keys := make([]string, 0, len(appErr.Context))
+	for key := range appErr.Context {
+		if key != "errors" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	fields := make([]LogField, 0, len(keys))
+	for _, key := range keys {
+		value := appErr.Context[key]
+		if slices.ContainsFunc(h.config.RedactContextKeys, func(redacted string) bool {
+			return strings.EqualFold(redacted, key)
+		}) {
+			value = "[REDACTED]"
+		} else {
+			value = truncateLogValue(value, maxLength)
+		}
+		fields = append(fields, LogField{Key: key, Value: value})
+	}
+
+	return fields
+}
+
+// truncateLogValue caps the printed size of value
+// Short values are kept as-is so loggers can encode them natively
+func truncateLogValue(value any, maxLength int) any {
+	text, ok := value.(string)
+	if !ok {
+		text = fmt.Sprint(value)
+	}
+	if len(text) <= maxLength {
+		return value
+	}
+	return text[:maxLength] + "...(truncated)"
+}
+
+// logMultiError logs batch errors as a single entry
+func (h *Handler) logMultiError(ctx *Context, statusCode int, multiErr *MultiError) {
+	fields := h.buildLogFields(ctx)
+	fields = append(fields,
+		LogField{Key: "status_code", Value: statusCode},
+		LogField{Key: "error_count", Value: multiErr.Len()},
+		LogField{Key: "errors", Value: multiErr.Error()},
+	)
+
+	if statusCode >= 500 {
+		h.logger.Error("Batch server error occurred", fields...)
+	} else {
+		h.logger.Warn("Batch client error occurred", fields...)
+	}
+}
+
 // logUnknownError logs unknown errors
 func (h *Handler) logUnknownError(ctx *Context, err error) {
 	fields := h.buildLogFields(ctx)
 	fields = append(fields, LogField{Key: "error", Value: err.Error()})
+	if h.config.IncludeStackTrace {
+		fields = append(fields, LogField{Key: "stacktrace", Value: string(debug.Stack())})
+	}
 	h.logger.Error("Unknown error occurred", fields...)
 }
 
@@ -0,0 +1,207 @@
+package response
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/fiqrioemry/go-api-toolkit/pagination"
+)
+
+func TestStackTraceLoggedNotSent(t *testing.T) {
+	config := DefaultConfig()
+	config.IncludeStackTrace = true
+	logger := NewRecordingLogger()
+	w := NewRecordingWriter()
+
+	h := NewHandler(WithLogger(logger), WithConfig(config))
+	h.HandleError(w, nil, NewInternalServerError("Failed to save user", errors.New("disk full")))
+
+	entry, ok := logger.Last()
+	if !ok {
+		t.Fatal("nothing was logged")
+	}
+	stack, ok := entry.Field("stacktrace")
+	if !ok {
+		t.Fatal("stacktrace field missing from log entry")
+	}
+	if !strings.Contains(stack.(string), "TestStackTraceLoggedNotSent") {
+		t.Errorf("stacktrace does not include the calling test:\n%s", stack)
+	}
+
+	body, err := json.Marshal(w.Body())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "TestStackTraceLoggedNotSent") || strings.Contains(string(body), "stack") {
+		t.Errorf("response body leaks the stack trace: %s", body)
+	}
+}
+
+func TestContextErrorMapping(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   ErrorCode
+	}{
+		{"wrapped canceled", fmt.Errorf("query users: %w", context.Canceled), http.StatusRequestTimeout, ErrCodeRequestCanceled},
+		{"deadline exceeded", context.DeadlineExceeded, http.StatusGatewayTimeout, ErrCodeTimeout},
+		{"app error wrapping deadline", NewExternalServiceError("Payment provider unavailable", context.DeadlineExceeded), http.StatusInternalServerError, ErrCodeExternalService},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := NewRecordingWriter()
+			NewHandler().HandleError(w, nil, tc.err)
+
+			response, ok := w.ErrorResponse()
+			if !ok {
+				t.Fatalf("body = %T, want ErrorResponse", w.Body())
+			}
+			if w.StatusCode() != tc.wantStatus || response.Code != tc.wantCode {
+				t.Errorf("got %d %s, want %d %s", w.StatusCode(), response.Code, tc.wantStatus, tc.wantCode)
+			}
+		})
+	}
+}
+
+func TestPaginationErrorsAreBadRequests(t *testing.T) {
+	_, sortErr := pagination.ValidateSort("secret", []string{"name"})
+	_, cursorErr := pagination.DecodeCursor("!")
+
+	for _, cause := range []error{
+		pagination.ValidateLimit(500),
+		sortErr,
+		cursorErr,
+		pagination.ErrInvalidRange,
+		pagination.ErrConflictingPagination,
+	} {
+		err := fmt.Errorf("invalid query parameters: %w", cause)
+
+		w := NewRecordingWriter()
+		NewHandler().HandleError(w, nil, err)
+
+		response, ok := w.ErrorResponse()
+		if !ok {
+			t.Fatalf("%v: body = %T, want ErrorResponse", cause, w.Body())
+		}
+		if w.StatusCode() != http.StatusBadRequest || response.Code != ErrCodeInvalidInput {
+			t.Errorf("%v: got %d %s, want 400 %s", cause, w.StatusCode(), response.Code, ErrCodeInvalidInput)
+		}
+		if response.Message != err.Error() {
+			t.Errorf("message = %q, want %q", response.Message, err.Error())
+		}
+	}
+}
+
+func TestStreamWritesNDJSON(t *testing.T) {
+	items := make(chan any, 3)
+	items <- map[string]int{"id": 1}
+	items <- map[string]int{"id": 2}
+	items <- "done"
+	close(items)
+
+	w := NewRecordingWriter()
+	if err := NewHandler().Stream(w, nil, items); err != nil {
+		t.Fatal(err)
+	}
+
+	contentType, body := w.Streamed()
+	if contentType != NDJSONContentType || w.StatusCode() != http.StatusOK {
+		t.Errorf("got %d %q, want 200 %q", w.StatusCode(), contentType, NDJSONContentType)
+	}
+	if want := "{\"id\":1}\n{\"id\":2}\n\"done\"\n"; string(body) != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+}
+
+func TestStreamStopsOnEncodeError(t *testing.T) {
+	items := make(chan any, 3)
+	items <- 1
+	items <- func() {} // not encodable
+	items <- 3
+	close(items)
+
+	w := NewRecordingWriter()
+	err := NewHandler().Stream(w, nil, items)
+
+	var unsupported *json.UnsupportedTypeError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("err = %v, want *json.UnsupportedTypeError", err)
+	}
+	if _, body := w.Streamed(); string(body) != "1\n" {
+		t.Errorf("body = %q, want only the items before the error", body)
+	}
+}
+
+// chunkRecorder records the output of each StreamChunks step, i.e. each flush
+type chunkRecorder struct {
+	chunks  []string
+	onFlush func()
+}
+
+func (r *chunkRecorder) JSON(int, any) {}
+
+func (r *chunkRecorder) StreamChunks(_ int, _ string, step func(w io.Writer) bool) {
+	for {
+		var buf bytes.Buffer
+		more := step(&buf)
+		if buf.Len() > 0 {
+			r.chunks = append(r.chunks, buf.String())
+			if r.onFlush != nil {
+				r.onFlush()
+			}
+		}
+		if !more {
+			return
+		}
+	}
+}
+
+func TestStreamFlushesInBatches(t *testing.T) {
+	items := make(chan any, 250)
+	for i := range 250 {
+		items <- i
+	}
+	close(items)
+
+	w := &chunkRecorder{}
+	if err := NewHandler().Stream(w, nil, items); err != nil {
+		t.Fatal(err)
+	}
+
+	var sizes []int
+	for _, chunk := range w.chunks {
+		sizes = append(sizes, strings.Count(chunk, "\n"))
+	}
+	if !slices.Equal(sizes, []int{100, 100, 50}) {
+		t.Errorf("items per flush = %v, want [100 100 50]", sizes)
+	}
+}
+
+func TestStreamFlushesWhenProducerIdle(t *testing.T) {
+	items := make(chan any)
+	flushed := make(chan struct{}, 2)
+	go func() {
+		items <- 1
+		<-flushed // the second item is only sent once the first was flushed
+		items <- 2
+		close(items)
+	}()
+
+	w := &chunkRecorder{onFlush: func() { flushed <- struct{}{} }}
+	if err := NewHandler().Stream(w, nil, items); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(w.chunks, []string{"1\n", "2\n"}) {
+		t.Errorf("chunks = %q, want one flush per item", w.chunks)
+	}
+}
@@ -0,0 +1,138 @@
+// ==================== response/keys.go ====================
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+)
+
+// EnvelopeKeys names the members of the response envelope
+// Empty keys keep the default name
+type EnvelopeKeys struct {
+	Success string // default "success"
+	Message string // default "message"
+	Data    string // default "data"
+	Meta    string // default "meta"
+	Code    string // default "code"
+	Errors  string // default "errors", field errors and batch item errors
+}
+
+// DefaultEnvelopeKeys returns the standard envelope member names
+func DefaultEnvelopeKeys() EnvelopeKeys {
+	return EnvelopeKeys{
+		Success: "success",
+		Message: "message",
+		Data:    "data",
+		Meta:    "meta",
+		Code:    "code",
+		Errors:  "errors",
+	}
+}
+
+// KeyEncoder renames envelope members, e.g. "errors" -> "fieldErrors"
+type KeyEncoder struct {
+	keys EnvelopeKeys
+}
+
+// NewKeyEncoder creates a ResponseEncoder that renames envelope members
+func NewKeyEncoder(keys EnvelopeKeys) *KeyEncoder {
+	defaults := DefaultEnvelopeKeys()
+	return &KeyEncoder{keys: EnvelopeKeys{
+		Success: firstNonEmpty(keys.Success, defaults.Success),
+		Message: firstNonEmpty(keys.Message, defaults.Message),
+		Data:    firstNonEmpty(keys.Data, defaults.Data),
+		Meta:    firstNonEmpty(keys.Meta, defaults.Meta),
+		Code:    firstNonEmpty(keys.Code, defaults.Code),
+		Errors:  firstNonEmpty(keys.Errors, defaults.Errors),
+	}}
+}
+
+func (k *KeyEncoder) Encode(statusCode int, envelope any) any {
+	switch response := envelope.(type) {
+	case SuccessResponse:
+		members := keyedEnvelope{
+			{k.keys.Success, response.Success},
+			{k.keys.Message, response.Message},
+		}
+		if response.Data != nil {
+			members = append(members, member{k.keys.Data, response.Data})
+		}
+		if response.Meta != nil {
+			members = append(members, member{k.keys.Meta, response.Meta})
+		}
+		return members
+	case ErrorResponse:
+		members := keyedEnvelope{
+			{k.keys.Success, response.Success},
+			{k.keys.Message, response.Message},
+			{k.keys.Code, response.Code},
+		}
+		if len(response.Errors) > 0 {
+			members = append(members, member{k.keys.Errors, xmlMap[any](response.Errors)})
+		}
+		return members
+	case MultiErrorResponse:
+		return keyedEnvelope{
+			{k.keys.Success, response.Success},
+			{k.keys.Message, response.Message},
+			{k.keys.Errors, itemErrors{Items: response.Errors}},
+		}
+	default:
+		return envelope
+	}
+}
+
+// member is one key/value pair of a keyedEnvelope
+type member struct {
+	key   string
+	value any
+}
+
+// keyedEnvelope is an envelope with custom member names, encoded in order
+type keyedEnvelope []member
+
+func (e keyedEnvelope) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range e {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (e keyedEnvelope) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "response"
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, m := range e {
+		if err := enc.EncodeElement(m.value, xml.StartElement{Name: xml.Name{Local: m.key}}); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// itemErrors renders batch item errors as a JSON array or repeated <error> elements
+type itemErrors struct {
+	Items []ItemError `xml:"error"`
+}
+
+func (i itemErrors) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.Items)
+}
@@ -0,0 +1,71 @@
+// ==================== response/metrics/prometheus.go ====================
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusObserver implements response.MetricsObserver with a CounterVec
+// labelled by method, status and error code. The path is left out on purpose:
+// raw paths contain IDs and would explode label cardinality.
+// It also implements response.DurationObserver with a latency histogram, fed
+// when response.MetricsMiddleware records the request start time.
+type PrometheusObserver struct {
+	responses *prometheus.CounterVec
+	durations *prometheus.HistogramVec
+}
+
+// NewPrometheusObserver registers the http_responses_total counter and the
+// http_response_duration_seconds histogram with registerer
+// Collectors that are already registered are reused.
+func NewPrometheusObserver(registerer prometheus.Registerer) (*PrometheusObserver, error) {
+	responses, err := register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_responses_total",
+		Help: "Total number of HTTP responses by method, status and error code.",
+	}, []string{"method", "status", "code"}))
+	if err != nil {
+		return nil, err
+	}
+
+	durations, err := register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_response_duration_seconds",
+		Help:    "HTTP response latency by method, status and error code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "status", "code"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrometheusObserver{responses: responses, durations: durations}, nil
+}
+
+// register registers collector, returning the existing one if already registered
+func register[T prometheus.Collector](registerer prometheus.Registerer, collector T) (T, error) {
+	if err := registerer.Register(collector); err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if !errors.As(err, &alreadyRegistered) {
+			return collector, err
+		}
+		existing, ok := alreadyRegistered.ExistingCollector.(T)
+		if !ok {
+			return collector, err
+		}
+		return existing, nil
+	}
+	return collector, nil
+}
+
+// Observe increments the counter for the response
+func (p *PrometheusObserver) Observe(path, method string, status int, code response.ErrorCode) {
+	p.responses.WithLabelValues(method, strconv.Itoa(status), string(code)).Inc()
+}
+
+// ObserveDuration records the response latency
+func (p *PrometheusObserver) ObserveDuration(path, method string, status int, code response.ErrorCode, duration time.Duration) {
+	p.durations.WithLabelValues(method, strconv.Itoa(status), string(code)).Observe(duration.Seconds())
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fiqrioemry/go-api-toolkit/response"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPrometheusObserverRecordsLatency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	registry := prometheus.NewRegistry()
+	observer, err := NewPrometheusObserver(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := response.NewHandler(
+		response.WithContextExtractor(response.GinContextExtractor),
+		response.WithMetricsObserver(observer),
+	)
+	router := gin.New()
+	router.Use(response.MetricsMiddleware())
+	router.GET("/users/:id", func(c *gin.Context) {
+		h.HandleError(&writer{c}, c, response.NewNotFound("User not found"))
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]uint64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			if counter := metric.GetCounter(); counter != nil {
+				counts[family.GetName()] += uint64(counter.GetValue())
+			}
+			if histogram := metric.GetHistogram(); histogram != nil {
+				counts[family.GetName()] += histogram.GetSampleCount()
+			}
+		}
+	}
+	if counts["http_responses_total"] != 1 || counts["http_response_duration_seconds"] != 1 {
+		t.Errorf("observations = %v, want one response and one duration", counts)
+	}
+
+	// Registering again reuses the existing collectors
+	if _, err := NewPrometheusObserver(registry); err != nil {
+		t.Errorf("second registration: %v", err)
+	}
+}
+
+// writer is a minimal gin-backed response.JSONWriter
+type writer struct{ c *gin.Context }
+
+func (w *writer) JSON(status int, obj any) { w.c.JSON(status, obj) }
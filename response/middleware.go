@@ -0,0 +1,157 @@
+// ==================== response/middleware.go ====================
+package response
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecoveryMiddleware recovers from panics and responds with a 500 error envelope
+// The panic is logged with the stack trace when Config.IncludeStackTrace is on;
+// the stack is never sent to the client. When the handler already started
+// writing the response, the panic is only logged and reported.
+func RecoveryMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				// Let net/http handle deliberate connection aborts
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				// Appending an envelope to a partial body would corrupt it
+				var writer JSONWriter
+				if !c.Writer.Written() {
+					writer = &GinJSONWriter{ctx: c}
+				}
+				getHandler().HandlePanic(writer, c, recovered, debug.Stack())
+				c.Abort()
+			}
+		}()
+		c.Next()
+	}
+}
+
+// MetricsMiddleware records when each request started, so a MetricsObserver
+// implementing DurationObserver also gets the response latency.
+// Register it first to include the time spent in other middleware.
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(ContextWithStartTime(c.Request.Context(), time.Now()))
+		c.Next()
+	}
+}
+
+// BodyLimitMiddleware caps the request body at maxBytes
+// Oversized requests get a 413 REQUEST_TOO_LARGE envelope, either up front from
+// Content-Length or when binding reads past the limit and the bind error is
+// passed to Error. Apply it per route or group for different limits.
+func BodyLimitMiddleware(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			Error(c, NewRequestTooLarge(fmt.Sprintf("Request body exceeds %d bytes", maxBytes)))
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
+
+// TraceIDOption configures TraceIDMiddleware
+type TraceIDOption func(*traceIDConfig)
+
+// traceIDConfig holds TraceIDMiddleware settings
+type traceIDConfig struct {
+	header    string
+	generator func() string
+}
+
+// WithTraceIDHeader sets the request/response header carrying the ID (default X-Request-Id)
+func WithTraceIDHeader(name string) TraceIDOption {
+	return func(c *traceIDConfig) {
+		c.header = name
+	}
+}
+
+// WithTraceIDGenerator sets the function generating new IDs (default UUID v4)
+func WithTraceIDGenerator(generator func() string) TraceIDOption {
+	return func(c *traceIDConfig) {
+		c.generator = generator
+	}
+}
+
+// TraceIDMiddleware assigns a correlation ID to every request
+// The ID is taken from the incoming header, then the W3C traceparent header,
+// and generated otherwise. It is stored as "trace_id" (picked up by
+// GinContextExtractor for logs), added to the request context.Context (see
+// TraceIDFromContext) and echoed back in the response header.
+func TraceIDMiddleware(options ...TraceIDOption) gin.HandlerFunc {
+	config := &traceIDConfig{
+		header:    "X-Request-Id",
+		generator: newUUID,
+	}
+	for _, opt := range options {
+		opt(config)
+	}
+
+	return func(c *gin.Context) {
+		traceID := SanitizeTraceID(c.GetHeader(config.header))
+		if traceID == "" {
+			traceID = traceIDFromTraceparent(c.GetHeader("traceparent"))
+		}
+		if traceID == "" {
+			traceID = config.generator()
+		}
+
+		c.Set("trace_id", traceID)
+		c.Request = c.Request.WithContext(ContextWithTraceID(c.Request.Context(), traceID))
+		c.Header(config.header, traceID)
+		c.Next()
+	}
+}
+
+// SanitizeTraceID returns id, or "" when a client-supplied ID is too long or
+// contains characters that could break log lines
+func SanitizeTraceID(id string) string {
+	if len(id) > 128 {
+		return ""
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return ""
+		}
+	}
+	return id
+}
+
+// traceIDFromTraceparent extracts the trace-id from a W3C traceparent header
+// Format: version-traceid-parentid-flags, e.g. 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func traceIDFromTraceparent(traceparent string) string {
+	parts := strings.Split(traceparent, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 {
+		return ""
+	}
+	for _, r := range parts[1] {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return ""
+		}
+	}
+	return parts[1]
+}
+
+// newUUID generates a random (version 4) UUID
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,117 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRecoveryMiddlewareAfterPartialWrite(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var reported *AppError
+	InitGin(InitConfig{OnServerError: func(ctx *Context, err *AppError) { reported = err }})
+	defer InitGin(InitConfig{})
+
+	router := gin.New()
+	router.Use(RecoveryMiddleware())
+	router.GET("/partial", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		panic("boom")
+	})
+	router.GET("/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/partial", nil))
+
+	if rec.Code != http.StatusOK || rec.Body.String() != "partial" {
+		t.Errorf("partial response = %d %q, want it left untouched", rec.Code, rec.Body.String())
+	}
+	if reported == nil {
+		t.Error("panic after a partial write was not reported")
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), `"code":"INTERNAL_SERVER_ERROR"`) {
+		t.Errorf("panic response = %d %s, want 500 envelope", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTraceIDFromTraceparent(t *testing.T) {
+	cases := map[string]string{
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01": "", // uppercase is invalid
+		"00-4bf92f3577b34da6-00f067aa0ba902b7-01":                 "", // short trace-id
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7":    "", // missing flags
+		"": "",
+	}
+	for header, want := range cases {
+		if got := traceIDFromTraceparent(header); got != want {
+			t.Errorf("traceIDFromTraceparent(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestSanitizeTraceID(t *testing.T) {
+	cases := map[string]string{
+		"req-123":                "req-123",
+		"abc def":                "",
+		"abc\nlevel=error":       "",
+		"café":                   "",
+		strings.Repeat("a", 128): strings.Repeat("a", 128),
+		strings.Repeat("a", 129): "",
+	}
+	for id, want := range cases {
+		if got := SanitizeTraceID(id); got != want {
+			t.Errorf("SanitizeTraceID(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestTraceIDMiddlewareEchoesHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(TraceIDMiddleware(
+		WithTraceIDHeader("X-Correlation-Id"),
+		WithTraceIDGenerator(func() string { return "generated" }),
+	))
+	var seen string
+	router.GET("/", func(c *gin.Context) {
+		seen = TraceIDFromContext(c.Request.Context())
+	})
+
+	cases := []struct {
+		name    string
+		headers map[string]string
+		want    string
+	}{
+		{"incoming header", map[string]string{"X-Correlation-Id": "req-123"}, "req-123"},
+		{"traceparent", map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}, "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"unsafe header", map[string]string{"X-Correlation-Id": "bad id"}, "generated"},
+		{"no header", nil, "generated"},
+	}
+
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		for key, value := range tc.headers {
+			req.Header.Set(key, value)
+		}
+		router.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("X-Correlation-Id"); got != tc.want {
+			t.Errorf("%s: response header = %q, want %q", tc.name, got, tc.want)
+		}
+		if seen != tc.want {
+			t.Errorf("%s: context trace ID = %q, want %q", tc.name, seen, tc.want)
+		}
+	}
+}
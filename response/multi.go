@@ -0,0 +1,110 @@
+// ==================== response/multi.go ====================
+package response
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// MultiError aggregates per-item errors for batch endpoints
+// Return it through ErrorOrNil: an empty MultiError passed to HandleError is
+// treated as an unknown error and sent as a plain 500.
+type MultiError struct {
+	items []indexedError
+}
+
+// indexedError is a failed batch item
+type indexedError struct {
+	index int
+	err   *AppError
+}
+
+// ItemError represents a failed batch item in the response
+type ItemError struct {
+	Index   int       `json:"index" xml:"index"`
+	Code    ErrorCode `json:"code" xml:"code"`
+	Message string    `json:"message" xml:"message"`
+}
+
+// MultiErrorResponse represents batch error response structure
+type MultiErrorResponse struct {
+	Success bool        `json:"success" xml:"success"`
+	Message string      `json:"message" xml:"message"`
+	Errors  []ItemError `json:"errors" xml:"errors>error"`
+}
+
+// batchProblemDetails is problem details with the failed items as the
+// "errors" extension member
+type batchProblemDetails struct {
+	ProblemDetails
+	Errors []ItemError `json:"errors"`
+}
+
+// NewMultiError creates an empty batch error
+func NewMultiError() *MultiError {
+	return &MultiError{}
+}
+
+// Add records the error for the item at index
+// A missing HTTPStatus is resolved with StatusForCode, as for single errors;
+// nil errors are ignored
+func (m *MultiError) Add(index int, err *AppError) {
+	if err == nil {
+		return
+	}
+	m.items = append(m.items, indexedError{index: index, err: resolveStatus(err)})
+}
+
+// Len returns the number of failed items
+func (m *MultiError) Len() int {
+	return len(m.items)
+}
+
+// ErrorOrNil returns nil when no item failed, so batches can `return errs.ErrorOrNil()`
+func (m *MultiError) ErrorOrNil() error {
+	if m == nil || len(m.items) == 0 {
+		return nil
+	}
+	return m
+}
+
+// HTTPStatus returns the most severe status among the item errors
+func (m *MultiError) HTTPStatus() int {
+	status := 0
+	for _, item := range m.items {
+		status = max(status, item.err.HTTPStatus)
+	}
+	if status == 0 {
+		return http.StatusInternalServerError
+	}
+	return status
+}
+
+// code returns the error code of the most severe item
+func (m *MultiError) code() ErrorCode {
+	status := m.HTTPStatus()
+	for _, item := range m.items {
+		if item.err.HTTPStatus == status {
+			return item.err.Code
+		}
+	}
+	return ErrCodeInternalServer
+}
+
+func (m *MultiError) Error() string {
+	messages := make([]string, len(m.items))
+	for i, item := range m.items {
+		messages[i] = fmt.Sprintf("[%d] %s", item.index, item.err.Error())
+	}
+	return fmt.Sprintf("%d items failed: %s", len(m.items), strings.Join(messages, "; "))
+}
+
+// Unwrap exposes the item errors to errors.Is/As
+func (m *MultiError) Unwrap() []error {
+	errs := make([]error, len(m.items))
+	for i, item := range m.items {
+		errs[i] = item.err
+	}
+	return errs
+}
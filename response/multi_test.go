@@ -0,0 +1,91 @@
+package response
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestMultiErrorResolvesMissingStatus(t *testing.T) {
+	errs := NewMultiError()
+	errs.Add(0, &AppError{Code: ErrCodeNotFound, Message: "missing"})
+	errs.Add(1, &AppError{Code: ErrCodeDatabaseError, Message: "db down"})
+
+	if status := errs.HTTPStatus(); status != http.StatusInternalServerError {
+		t.Errorf("HTTPStatus = %d, want 500", status)
+	}
+	if code := errs.code(); code != ErrCodeDatabaseError {
+		t.Errorf("code = %s, want %s", code, ErrCodeDatabaseError)
+	}
+
+	config := DefaultConfig()
+	config.ProductionMode = true
+	w := NewRecordingWriter()
+	NewHandler(WithConfig(config)).HandleError(w, nil, errs)
+
+	response, ok := w.Body().(MultiErrorResponse)
+	if !ok {
+		t.Fatalf("body = %T, want MultiErrorResponse", w.Body())
+	}
+	if got := response.Errors[0].Message; got != "missing" {
+		t.Errorf("client error message = %q, want %q", got, "missing")
+	}
+	if got := response.Errors[1].Message; got != "Internal server error" {
+		t.Errorf("server error message = %q, want it masked", got)
+	}
+}
+
+func TestMultiErrorIgnoresNil(t *testing.T) {
+	errs := NewMultiError()
+	errs.Add(0, nil)
+
+	if errs.Len() != 0 || errs.ErrorOrNil() != nil {
+		t.Errorf("Len = %d, want nil error for an empty batch", errs.Len())
+	}
+}
+
+func TestMultiErrorProblemDetails(t *testing.T) {
+	errs := NewMultiError()
+	errs.Add(2, NewConflict("SKU already exists"))
+
+	config := DefaultConfig()
+	config.ProblemDetails = true
+	w := NewRecordingWriter()
+	NewHandler(WithConfig(config)).HandleError(w, nil, errs)
+
+	if ct := w.Header("Content-Type"); ct != ProblemContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, ProblemContentType)
+	}
+	problem, ok := w.Body().(batchProblemDetails)
+	if !ok {
+		t.Fatalf("body = %T, want batchProblemDetails", w.Body())
+	}
+	if problem.Status != http.StatusConflict || problem.Type != "urn:problem-type:conflict" {
+		t.Errorf("status = %d, type = %q", problem.Status, problem.Type)
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0].Index != 2 {
+		t.Errorf("errors = %+v, want item 2", problem.Errors)
+	}
+}
+
+func TestIsServerErrorUsesMostSevereItem(t *testing.T) {
+	errs := NewMultiError()
+	errs.Add(0, NewNotFound("missing"))
+	errs.Add(1, NewInternalServerError("boom", nil))
+
+	if !IsServerError(errs) {
+		t.Error("IsServerError = false, want true for a batch with a 500 item")
+	}
+}
+
+func TestEmptyMultiErrorIsUnknownError(t *testing.T) {
+	w := NewRecordingWriter()
+	NewHandler().HandleError(w, nil, NewMultiError())
+
+	response, ok := w.ErrorResponse()
+	if !ok {
+		t.Fatalf("body = %T, want ErrorResponse", w.Body())
+	}
+	if w.StatusCode() != http.StatusInternalServerError || response.Code != ErrCodeInternalServer {
+		t.Errorf("got %d %s, want 500 %s", w.StatusCode(), response.Code, ErrCodeInternalServer)
+	}
+}
@@ -0,0 +1,144 @@
+// ==================== response/ratelimit.go ====================
+package response
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitStore decides whether a request for key may proceed
+// Implement it to share limits across instances, e.g. backed by Redis
+type RateLimitStore interface {
+	// Allow consumes one request for key; when it's denied, retryAfter is
+	// the time until the next request would be allowed
+	Allow(key string) (allowed bool, retryAfter time.Duration)
+}
+
+// RateLimitOption configures RateLimitMiddleware
+type RateLimitOption func(*rateLimitConfig)
+
+// rateLimitConfig holds RateLimitMiddleware settings
+type rateLimitConfig struct {
+	keyFunc func(c *gin.Context) string
+	store   RateLimitStore
+}
+
+// WithRateLimitKey sets how requests are grouped (default: client IP)
+func WithRateLimitKey(keyFunc func(c *gin.Context) string) RateLimitOption {
+	return func(cfg *rateLimitConfig) {
+		cfg.keyFunc = keyFunc
+	}
+}
+
+// WithRateLimitStore replaces the in-memory store; rps and burst are then
+// up to the store
+func WithRateLimitStore(store RateLimitStore) RateLimitOption {
+	return func(cfg *rateLimitConfig) {
+		cfg.store = store
+	}
+}
+
+// RateLimitMiddleware limits each client to rps requests per second with bursts
+// of up to burst requests. Rejected requests get a 429 TOO_MANY_REQUESTS error
+// with a Retry-After header.
+func RateLimitMiddleware(rps int, burst int, options ...RateLimitOption) gin.HandlerFunc {
+	config := &rateLimitConfig{
+		keyFunc: func(c *gin.Context) string {
+			return c.ClientIP()
+		},
+	}
+	for _, opt := range options {
+		opt(config)
+	}
+	if config.store == nil {
+		config.store = NewMemoryRateLimitStore(rps, burst)
+	}
+
+	return func(c *gin.Context) {
+		allowed, retryAfter := config.store.Allow(config.keyFunc(c))
+		if !allowed {
+			Error(c, NewTooManyRequests("Too many requests").WithRetryAfter(retryAfter))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// MemoryRateLimitStore is an in-memory token bucket per key
+// Idle buckets are dropped periodically, so memory stays bounded by active clients
+type MemoryRateLimitStore struct {
+	mu          sync.Mutex
+	rate        float64 // tokens per second
+	burst       float64
+	buckets     map[string]*tokenBucket
+	lastCleanup time.Time
+	now         func() time.Time // time.Now, replaced in tests
+}
+
+// tokenBucket is the state of one key
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewMemoryRateLimitStore creates a token bucket store
+// rps below 1 is treated as 1; burst below 1 defaults to rps
+func NewMemoryRateLimitStore(rps int, burst int) *MemoryRateLimitStore {
+	rps = max(rps, 1)
+	if burst < 1 {
+		burst = rps
+	}
+	return &MemoryRateLimitStore{
+		rate:        float64(rps),
+		burst:       float64(burst),
+		buckets:     make(map[string]*tokenBucket),
+		lastCleanup: time.Now(),
+		now:         time.Now,
+	}
+}
+
+// Allow consumes a token for key
+func (s *MemoryRateLimitStore) Allow(key string) (bool, time.Duration) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cleanup(now)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: s.burst, last: now}
+		s.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(s.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*s.rate)
+	bucket.last = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	wait := (1 - bucket.tokens) / s.rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// cleanup drops buckets that have refilled completely, at most once a minute
+func (s *MemoryRateLimitStore) cleanup(now time.Time) {
+	if now.Sub(s.lastCleanup) < time.Minute {
+		return
+	}
+	s.lastCleanup = now
+
+	refill := time.Duration(s.burst / s.rate * float64(time.Second))
+	for key, bucket := range s.buckets {
+		if now.Sub(bucket.last) > refill {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeClock is a manually advanced clock for MemoryRateLimitStore
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestStore(rps, burst int) (*MemoryRateLimitStore, *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	store := NewMemoryRateLimitStore(rps, burst)
+	store.now = clock.Now
+	store.lastCleanup = clock.now
+	return store, clock
+}
+
+func TestMemoryRateLimitStoreAllow(t *testing.T) {
+	store, clock := newTestStore(2, 3)
+
+	for i := range 3 {
+		if allowed, _ := store.Allow("a"); !allowed {
+			t.Fatalf("request %d within burst was denied", i+1)
+		}
+	}
+
+	allowed, retryAfter := store.Allow("a")
+	if allowed {
+		t.Fatal("request beyond burst was allowed")
+	}
+	if retryAfter != 500*time.Millisecond {
+		t.Errorf("retryAfter = %v, want 500ms at 2 rps", retryAfter)
+	}
+
+	if allowed, _ := store.Allow("b"); !allowed {
+		t.Error("other key shares the bucket")
+	}
+
+	clock.Advance(250 * time.Millisecond)
+	if allowed, retryAfter = store.Allow("a"); allowed || retryAfter != 250*time.Millisecond {
+		t.Errorf("after 250ms: allowed = %v, retryAfter = %v, want false, 250ms", allowed, retryAfter)
+	}
+
+	clock.Advance(250 * time.Millisecond)
+	if allowed, _ := store.Allow("a"); !allowed {
+		t.Error("request after refill was denied")
+	}
+}
+
+func TestMemoryRateLimitStoreCleanup(t *testing.T) {
+	store, clock := newTestStore(1, 2)
+	store.Allow("idle")
+
+	clock.Advance(time.Minute)
+	store.Allow("active")
+
+	if _, ok := store.buckets["idle"]; ok {
+		t.Error("idle bucket was not dropped")
+	}
+	if _, ok := store.buckets["active"]; !ok {
+		t.Error("active bucket was dropped")
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store, _ := newTestStore(1, 1)
+
+	router := gin.New()
+	router.Use(RateLimitMiddleware(1, 1, WithRateLimitStore(store)))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("first request = %d, want 204", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "1" {
+		t.Errorf("second request = %d, Retry-After %q, want 429 and 1", rec.Code, rec.Header().Get("Retry-After"))
+	}
+}
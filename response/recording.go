@@ -0,0 +1,201 @@
+// ==================== response/recording.go ====================
+package response
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// RecordingWriter is a JSONWriter for unit tests
+// It records the last response instead of writing it, so handlers can be
+// tested without a framework. It implements every optional writer interface.
+type RecordingWriter struct {
+	mu          sync.Mutex
+	statusCode  int
+	body        any
+	format      string
+	contentType string
+	headers     map[string]string
+	streamed    []byte
+}
+
+// NewRecordingWriter creates an empty RecordingWriter
+func NewRecordingWriter() *RecordingWriter {
+	return &RecordingWriter{headers: make(map[string]string)}
+}
+
+func (r *RecordingWriter) JSON(statusCode int, obj any) {
+	r.record(statusCode, obj, "json")
+}
+
+func (r *RecordingWriter) XML(statusCode int, obj any) {
+	r.record(statusCode, obj, "xml")
+}
+
+func (r *RecordingWriter) Status(statusCode int) {
+	r.record(statusCode, nil, "status")
+}
+
+func (r *RecordingWriter) Stream(statusCode int, contentType string, body io.Reader) error {
+	data, err := io.ReadAll(body)
+	r.record(statusCode, nil, "stream")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.contentType = contentType
+	r.streamed = data
+	return err
+}
+
+func (r *RecordingWriter) StreamChunks(statusCode int, contentType string, step func(w io.Writer) bool) {
+	var buf bytes.Buffer
+	for step(&buf) {
+	}
+	r.record(statusCode, nil, "stream")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.contentType = contentType
+	r.streamed = buf.Bytes()
+}
+
+func (r *RecordingWriter) SetHeader(key, value string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.headers == nil {
+		r.headers = make(map[string]string)
+	}
+	r.headers[key] = value
+}
+
+// record stores the last response
+func (r *RecordingWriter) record(statusCode int, obj any, format string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statusCode = statusCode
+	r.body = obj
+	r.format = format
+}
+
+// StatusCode returns the recorded status, 0 if nothing was written
+func (r *RecordingWriter) StatusCode() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.statusCode
+}
+
+// Body returns the recorded object, e.g. a SuccessResponse or ErrorResponse
+func (r *RecordingWriter) Body() any {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.body
+}
+
+// Format returns how the response was written: "json", "xml", "status" or "stream"
+func (r *RecordingWriter) Format() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.format
+}
+
+// Header returns a recorded response header
+func (r *RecordingWriter) Header(key string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.headers[key]
+}
+
+// Streamed returns the content type and body written with Stream or StreamChunks
+func (r *RecordingWriter) Streamed() (contentType string, body []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.contentType, r.streamed
+}
+
+// ErrorResponse returns the body as an ErrorResponse, if it is one
+func (r *RecordingWriter) ErrorResponse() (ErrorResponse, bool) {
+	response, ok := r.Body().(ErrorResponse)
+	return response, ok
+}
+
+// SuccessResponse returns the body as a SuccessResponse, if it is one
+func (r *RecordingWriter) SuccessResponse() (SuccessResponse, bool) {
+	response, ok := r.Body().(SuccessResponse)
+	return response, ok
+}
+
+// LogEntry is a log call captured by RecordingLogger
+type LogEntry struct {
+	Level   LogLevel
+	Message string
+	Fields  []LogField
+}
+
+// Field returns the value of the first field with key
+func (e LogEntry) Field(key string) (any, bool) {
+	for _, field := range e.Fields {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// RecordingLogger is a Logger for unit tests that keeps every call
+type RecordingLogger struct {
+	mu      sync.Mutex
+	entries []LogEntry
+}
+
+// NewRecordingLogger creates an empty RecordingLogger
+func NewRecordingLogger() *RecordingLogger {
+	return &RecordingLogger{}
+}
+
+func (l *RecordingLogger) Debug(msg string, fields ...LogField) {
+	l.record(LogLevelDebug, msg, fields)
+}
+
+func (l *RecordingLogger) Info(msg string, fields ...LogField) {
+	l.record(LogLevelInfo, msg, fields)
+}
+
+func (l *RecordingLogger) Warn(msg string, fields ...LogField) {
+	l.record(LogLevelWarn, msg, fields)
+}
+
+func (l *RecordingLogger) Error(msg string, fields ...LogField) {
+	l.record(LogLevelError, msg, fields)
+}
+
+// record appends a log call
+func (l *RecordingLogger) record(level LogLevel, msg string, fields []LogField) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, LogEntry{Level: level, Message: msg, Fields: fields})
+}
+
+// Entries returns a copy of the recorded log calls
+func (l *RecordingLogger) Entries() []LogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]LogEntry(nil), l.entries...)
+}
+
+// Last returns the most recent log call
+func (l *RecordingLogger) Last() (LogEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.entries) == 0 {
+		return LogEntry{}, false
+	}
+	return l.entries[len(l.entries)-1], true
+}
+
+// Reset clears the recorded log calls
+func (l *RecordingLogger) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = nil
+}
@@ -0,0 +1,47 @@
+// ==================== response/slog.go ====================
+package response
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogLogger implements Logger interface for log/slog
+type SlogLogger struct {
+	logger *slog.Logger
+}
+
+func NewSlogLogger(logger *slog.Logger) *SlogLogger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogLogger{logger: logger}
+}
+
+func (s *SlogLogger) Debug(msg string, fields ...LogField) {
+	s.log(slog.LevelDebug, msg, fields)
+}
+
+func (s *SlogLogger) Info(msg string, fields ...LogField) {
+	s.log(slog.LevelInfo, msg, fields)
+}
+
+func (s *SlogLogger) Warn(msg string, fields ...LogField) {
+	s.log(slog.LevelWarn, msg, fields)
+}
+
+func (s *SlogLogger) Error(msg string, fields ...LogField) {
+	s.log(slog.LevelError, msg, fields)
+}
+
+func (s *SlogLogger) log(level slog.Level, msg string, fields []LogField) {
+	s.logger.LogAttrs(context.Background(), level, msg, s.convertFields(fields)...)
+}
+
+func (s *SlogLogger) convertFields(fields []LogField) []slog.Attr {
+	attrs := make([]slog.Attr, len(fields))
+	for i, field := range fields {
+		attrs[i] = slog.Any(field.Key, field.Value)
+	}
+	return attrs
+}
@@ -1,7 +1,12 @@
 package response
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
+	"runtime"
+	"strings"
+	"time"
 )
 
 // ErrorCode represents application error codes
@@ -16,20 +21,30 @@ const (
 	ErrCodeConflict        ErrorCode = "CONFLICT"
 	ErrCodeRequestTooLarge ErrorCode = "REQUEST_TOO_LARGE"
 	ErrCodeTooManyRequest  ErrorCode = "TOO_MANY_REQUESTS"
+	ErrCodeValidation      ErrorCode = "VALIDATION_ERROR"
+	ErrCodeRequestCanceled ErrorCode = "REQUEST_CANCELED"
 
 	// Server errors (5xx)
 	ErrCodeInternalServer  ErrorCode = "INTERNAL_SERVER_ERROR"
 	ErrCodeDatabaseError   ErrorCode = "DATABASE_ERROR"
 	ErrCodeExternalService ErrorCode = "EXTERNAL_SERVICE_ERROR"
+	ErrCodeTimeout         ErrorCode = "TIMEOUT"
 )
 
+// FieldErrors maps field names to validation messages
+type FieldErrors map[string]string
+
 // AppError represents application error with context
 type AppError struct {
-	Code       ErrorCode      `json:"code"`
-	Message    string         `json:"message"`
-	HTTPStatus int            `json:"-"`
-	Err        error          `json:"-"`
-	Context    map[string]any `json:"context,omitempty"`
+	Code       ErrorCode         `json:"code"`
+	Message    string            `json:"message"`
+	HTTPStatus int               `json:"-"`
+	Err        error             `json:"-"`
+	Context    map[string]any    `json:"context,omitempty"`
+	RetryAfter time.Duration     `json:"-"` // Sent as Retry-After header when set
+	Headers    map[string]string `json:"-"` // Extra response headers
+	stack      []uintptr         // Captured for server errors, never serialized
+	sentinel   bool              // Shared package-level value, copied on write
 }
 
 func (e *AppError) Error() string {
@@ -43,7 +58,45 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
+// StackTrace returns the stack captured when the error was created, if any
+func (e *AppError) StackTrace() string {
+	if len(e.stack) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// Is matches any AppError with the same Code, so errors.Is(err, ErrNotFound) works
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code
+}
+
+// mutable returns e, or a copy when e is a shared sentinel so the setters
+// never change package-level errors
+func (e *AppError) mutable() *AppError {
+	if !e.sentinel {
+		return e
+	}
+	clone := *e
+	clone.sentinel = false
+	clone.Context = maps.Clone(e.Context)
+	clone.Headers = maps.Clone(e.Headers)
+	return &clone
+}
+
 func (e *AppError) WithContext(key string, value any) *AppError {
+	e = e.mutable()
 	if e.Context == nil {
 		e.Context = make(map[string]any)
 	}
@@ -51,27 +104,96 @@ func (e *AppError) WithContext(key string, value any) *AppError {
 	return e
 }
 
+// WithCode overrides the error code
+func (e *AppError) WithCode(code ErrorCode) *AppError {
+	e = e.mutable()
+	e.Code = code
+	return e
+}
+
+// WithStatus overrides the HTTP status
+func (e *AppError) WithStatus(httpStatus int) *AppError {
+	e = e.mutable()
+	e.HTTPStatus = httpStatus
+	return e
+}
+
+// WithHeader adds a response header sent with the error
+func (e *AppError) WithHeader(key, value string) *AppError {
+	e = e.mutable()
+	if e.Headers == nil {
+		e.Headers = make(map[string]string)
+	}
+	e.Headers[key] = value
+	return e
+}
+
+// WithRetryAfter tells the client when it may retry, typically for 429/503
+func (e *AppError) WithRetryAfter(d time.Duration) *AppError {
+	e = e.mutable()
+	e.RetryAfter = d
+	return e
+}
+
 // ErrorResponse represents error response structure
 type ErrorResponse struct {
-	Success bool           `json:"success"`
-	Message string         `json:"message"`
-	Code    ErrorCode      `json:"code"`
-	Errors  map[string]any `json:"errors,omitempty"`
+	Success bool           `json:"success" xml:"success"`
+	Message string         `json:"message" xml:"message"`
+	Code    ErrorCode      `json:"code" xml:"code"`
+	Errors  map[string]any `json:"errors,omitempty" xml:"-"` // Rendered by MarshalXML
+}
+
+// ProblemContentType is the media type for RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails represents RFC 7807 error response structure
+type ProblemDetails struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Errors   map[string]any `json:"errors,omitempty"` // Extension member for field errors
 }
 
 // SuccessResponse represents success response structure
 type SuccessResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	Data    any    `json:"data,omitempty"`
-	Meta    *Meta  `json:"meta,omitempty"`
+	Success bool   `json:"success" xml:"success"`
+	Message string `json:"message" xml:"message"`
+	Data    any    `json:"data,omitempty" xml:"-"` // Rendered by MarshalXML
+	Meta    *Meta  `json:"meta,omitempty" xml:"meta,omitempty"`
+}
+
+// DataOf returns resp.Data as T, e.g. in tests or typed API clients
+// Data decoded from JSON (maps, slices, float64s) is converted by
+// re-encoding it into T.
+func DataOf[T any](resp SuccessResponse) (T, error) {
+	var data T
+	if resp.Data == nil {
+		return data, nil
+	}
+	if typed, ok := resp.Data.(T); ok {
+		return typed, nil
+	}
+
+	raw, err := json.Marshal(resp.Data)
+	if err != nil {
+		return data, fmt.Errorf("encoding response data: %w", err)
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return data, fmt.Errorf("decoding response data as %T: %w", data, err)
+	}
+	return data, nil
 }
 
 // Meta represents metadata for responses
+// Map fields are rendered by MarshalXML
 type Meta struct {
-	Pagination  any             `json:"pagination,omitempty"` // Interface{} to accept any pagination type
-	Permissions map[string]bool `json:"permissions,omitempty"`
-	Flags       map[string]bool `json:"flags,omitempty"`
+	Pagination  any               `json:"pagination,omitempty" xml:"-"` // Interface{} to accept any pagination type
+	Permissions map[string]bool   `json:"permissions,omitempty" xml:"-"`
+	Flags       map[string]bool   `json:"flags,omitempty" xml:"-"`
+	Links       map[string]string `json:"links,omitempty" xml:"-"`
+	Warnings    []string          `json:"warnings,omitempty" xml:"warnings>warning,omitempty"` // Non-fatal notices
 }
 
 // Context represents request context for logging
@@ -82,5 +204,7 @@ type Context struct {
 	UserAgent string
 	UserID    string
 	TraceID   string
+	Accept    string // Accept header, used for content negotiation
 	Headers   map[string]string
+	StartTime time.Time // When the request started, set by MetricsMiddleware
 }
@@ -0,0 +1,144 @@
+// ==================== response/xml.go ====================
+package response
+
+import (
+	"encoding/xml"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// xmlRoot is the root element name for XML envelopes
+var xmlRoot = xml.Name{Local: "response"}
+
+// xmlMap renders a map as <entry key="...">value</entry> elements
+// encoding/xml can't marshal maps, so envelope maps go through this type
+type xmlMap[V any] map[string]V
+
+func (m xmlMap[V]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		entry := xml.StartElement{
+			Name: xml.Name{Local: "entry"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}},
+		}
+		if err := e.EncodeElement(m[key], entry); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// xmlValue converts maps with string keys, including maps nested in slices
+// and other maps, into xmlMap so common data shapes can be rendered
+func xmlValue(value any) any {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return value
+		}
+		m := make(xmlMap[any], v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[iter.Key().String()] = xmlValue(iter.Value().Interface())
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return value
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = xmlValue(v.Index(i).Interface())
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+// MarshalXML renders the success envelope under a <response> root
+func (r SuccessResponse) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type plain SuccessResponse
+	start.Name = xmlRoot
+	return e.EncodeElement(struct {
+		plain
+		Data any `xml:"data,omitempty"`
+	}{plain(r), xmlValue(r.Data)}, start)
+}
+
+// MarshalXML renders the error envelope under a <response> root, including field errors
+func (r ErrorResponse) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type plain ErrorResponse
+	start.Name = xmlRoot
+	return e.EncodeElement(struct {
+		plain
+		Errors xmlMap[any] `xml:"errors,omitempty"`
+	}{plain(r), r.Errors}, start)
+}
+
+// MarshalXML renders metadata including its map fields
+func (m Meta) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type plain Meta
+	return e.EncodeElement(struct {
+		plain
+		Permissions xmlMap[bool]   `xml:"permissions,omitempty"`
+		Flags       xmlMap[bool]   `xml:"flags,omitempty"`
+		Links       xmlMap[string] `xml:"links,omitempty"`
+		Pagination  any            `xml:"pagination,omitempty"`
+	}{plain(m), m.Permissions, m.Flags, m.Links, xmlValue(m.Pagination)}, start)
+}
+
+// prefersXML reports whether an Accept header ranks XML above JSON
+// Wildcards count towards JSON, so */* keeps the JSON default
+func prefersXML(accept string) bool {
+	if accept == "" {
+		return false
+	}
+
+	jsonQ, xmlQ := -1.0, -1.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && key == "q" {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+
+		switch strings.ToLower(strings.TrimSpace(mediaType)) {
+		case "application/json", "application/*", "*/*":
+			jsonQ = max(jsonQ, quality)
+		case "application/xml", "text/xml":
+			xmlQ = max(xmlQ, quality)
+		}
+	}
+
+	return xmlQ > 0 && xmlQ > jsonQ
+}
+
+// nullData renders as an explicit "data": null in JSON and is omitted in XML
+type nullData struct{}
+
+func (nullData) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+func (nullData) MarshalXML(*xml.Encoder, xml.StartElement) error {
+	return nil
+}
@@ -0,0 +1,62 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func negotiatingHandler() *Handler {
+	config := DefaultConfig()
+	config.EnableContentNegotiation = true
+	return NewHandler(WithConfig(config), WithContextExtractor(GinContextExtractor))
+}
+
+func xmlRequest(t *testing.T, render func(h *Handler, c *gin.Context)) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodGet, "/items", nil)
+	c.Request.Header.Set("Accept", "application/xml")
+	render(negotiatingHandler(), c)
+	return rec
+}
+
+func TestXMLRendersMapData(t *testing.T) {
+	rec := xmlRequest(t, func(h *Handler, c *gin.Context) {
+		data := map[string]any{"id": 1, "tags": []string{"a", "b"}}
+		pag := map[string]int{"page": 2}
+		h.OKWithPagination(&GinJSONWriter{ctx: c}, c, "ok", data, pag)
+	})
+
+	if ct := rec.Header().Get("Content-Type"); !strings.Contains(ct, "xml") {
+		t.Fatalf("Content-Type = %q, want xml", ct)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`<entry key="id">1</entry>`,
+		`<entry key="tags">a</entry><entry key="tags">b</entry>`,
+		`<pagination><entry key="page">2</entry></pagination>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body %s missing %s", body, want)
+		}
+	}
+}
+
+func TestXMLFallsBackToJSON(t *testing.T) {
+	rec := xmlRequest(t, func(h *Handler, c *gin.Context) {
+		h.OK(&GinJSONWriter{ctx: c}, c, "ok", map[int]string{1: "one"})
+	})
+
+	if ct := rec.Header().Get("Content-Type"); !strings.Contains(ct, "json") {
+		t.Fatalf("Content-Type = %q, want json", ct)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, `"data":{"1":"one"}`) {
+		t.Errorf("body = %s, want JSON data", body)
+	}
+}